@@ -1,21 +1,66 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"gladiator-sim/game"
 	"gladiator-sim/ui"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
 func main() {
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for the run's random rolls, reuse it to replay a run")
+	enemiesPath := flag.String("enemies", "", "enemy roster JSON file to use instead of the built-in one")
+	upgradesPath := flag.String("upgrades", "", "upgrade catalog JSON file to use instead of the built-in one")
+	arenasPath := flag.String("arenas", "", "arena condition table JSON file to use instead of the built-in one")
+	flag.Parse()
+
+	var enemies *game.EnemyRoster
+	if *enemiesPath != "" {
+		roster, err := game.LoadEnemyRoster(*enemiesPath)
+		if err != nil {
+			fmt.Println("Error loading enemies:", err)
+			return
+		}
+		enemies = roster
+	}
+
+	var upgrades *game.UpgradeCatalog
+	if *upgradesPath != "" {
+		catalog, err := game.LoadUpgradeCatalog(*upgradesPath)
+		if err != nil {
+			fmt.Println("Error loading upgrades:", err)
+			return
+		}
+		upgrades = catalog
+	}
+
+	var arenas *game.ArenaTable
+	if *arenasPath != "" {
+		table, err := game.LoadArenaTable(*arenasPath)
+		if err != nil {
+			fmt.Println("Error loading arena conditions:", err)
+			return
+		}
+		arenas = table
+	}
+
 	// Start the UI
 	screen, err := tcell.NewScreen()
 	if err != nil {
 		fmt.Println("Error creating screen:", err)
 		return
 	}
-	defer screen.Fini()
+	// Problems that do not stop the game are reported once the terminal is restored
+	var warnings []string
+	defer func() {
+		screen.Fini()
+		for _, warning := range warnings {
+			fmt.Println(warning)
+		}
+	}()
 
 	if err := screen.Init(); err != nil {
 		fmt.Println("Error initializing screen:", err)
@@ -23,18 +68,69 @@ func main() {
 	}
 	screen.Clear()
 
-	// Start the game
-	playerName := ui.ShowStartScreen(screen)
+	// Runs are saved automatically, without a data directory the game still works unsaved
+	savePath, err := game.SavePath()
+	if err != nil {
+		savePath = ""
+	}
 
-	gameHandler := &game.GameHandler{}
-	hero := game.NewHero(playerName)
-	gameState := game.NewGameState()
+	// Gold and unlocks carry over between runs, a broken profile is left alone and replaced for this session
+	profile := game.NewProfile()
+	profilePath, err := game.ProfilePath()
+	if err == nil {
+		loaded, err := game.LoadProfile(profilePath)
+		if err == nil {
+			profile = loaded
+		} else {
+			profilePath = ""
+			warnings = append(warnings, fmt.Sprintf("Could not load your profile, progress is not saved: %v", err))
+		}
+	} else {
+		profilePath = ""
+	}
+
+	gameHandler := &game.GameHandler{
+		SavePath:    savePath,
+		Enemies:     enemies,
+		Upgrades:    upgrades,
+		Arenas:      arenas,
+		Profile:     profile,
+		ProfilePath: profilePath,
+	}
 
-	enemy := gameHandler.CreateEnemy(gameState.CurrentEnemy)
+	// Start the game
+	classes := gameHandler.ClassOptions()
+	patrons := []ui.MenuOption{}
+	for _, patron := range game.Patrons() {
+		patrons = append(patrons, ui.MenuOption{
+			Name:        patron.Name,
+			Description: fmt.Sprintf("%s. %s. %s: %s", patron.Description, patron.PassiveText, patron.Blessing, patron.BlessingText),
+		})
+	}
+	choice := ui.ShowStartScreen(screen, game.HasSave(savePath), classes, patrons)
 
 	quit := make(chan bool)
 	done := make(chan bool)
 
+	if choice.Continue {
+		hero, enemy, gameState, err := gameHandler.LoadRun(savePath)
+		if err == nil {
+			gameState.Narrate("Welcome back! Your run has been restored.")
+			ui.StartInputHandler(screen, hero, enemy, gameState, gameHandler, quit, done)
+			ui.DrawUI(screen, hero, enemy, gameState)
+			<-quit
+			return
+		}
+		choice.PlayerName = "Hero"
+		warnings = append(warnings, fmt.Sprintf("Could not continue the saved run: %v", err))
+	}
+
+	hero := gameHandler.NewHero(choice.PlayerName, choice.Class, choice.Patron)
+	gameState := game.NewGameState(*seed)
+	gameState.Tactical = choice.Tactical
+
+	enemy := gameHandler.CreateEnemy(gameState.Rng, gameState.CurrentEnemy)
+
 	ui.StartInputHandler(screen, hero, enemy, gameState, gameHandler, quit, done)
 
 	gameHandler.StartBattle(hero, enemy, screen, gameState, quit, done)
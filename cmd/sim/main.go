@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"gladiator-sim/game"
+	model "gladiator-sim/models"
+)
+
+// runResult is the outcome of a single simulated run
+type runResult struct {
+	Won        bool
+	DeathLevel int    // Enemy level the hero died at, 0 if the run was won
+	DeathEnemy string // Name of the enemy that killed the hero
+	// Enemy level at which neither side could kill the other within the turn cap, 0 if none
+	StalemateLevel int
+	Battles        int
+	Turns          int
+	Depth          int // Endless depth the hero died at, 0 if it never went past the final boss
+}
+
+func main() {
+	runs := flag.Int("runs", 1000, "number of full runs to simulate")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed every run's own seed is derived from")
+	maxTurns := flag.Int("max-turns", 1000, "attacks after which a battle is counted as a stalemate")
+	policyName := flag.String("policy", "random", "upgrade picking policy (first, heal, random)")
+	logPath := flag.String("log", "", "write every battle log entry as JSON lines to this file")
+	className := flag.String("class", game.HeroClasses()[0].Name, "hero class every run is played as")
+	patronName := flag.String("patron", "", "patron god every run is pledged to, empty for none")
+	enemiesPath := flag.String("enemies", "", "enemy roster JSON file to use instead of the built-in one")
+	upgradesPath := flag.String("upgrades", "", "upgrade catalog JSON file to use instead of the built-in one")
+	arenasPath := flag.String("arenas", "", "arena condition table JSON file to use instead of the built-in one")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of runs simulated in parallel")
+	endless := flag.Bool("endless", false, "continue won runs in endless mode until the hero dies")
+	flag.Parse()
+
+	policy, err := lookupPolicy(*policyName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	if _, ok := game.FindHeroClass(*className); !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown class %q\n", *className)
+		os.Exit(2)
+	}
+	if _, ok := game.FindPatron(*patronName); !ok && *patronName != "" {
+		fmt.Fprintf(os.Stderr, "Error: unknown patron %q\n", *patronName)
+		os.Exit(2)
+	}
+
+	var enemies *game.EnemyRoster
+	if *enemiesPath != "" {
+		enemies, err = game.LoadEnemyRoster(*enemiesPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
+
+	var upgrades *game.UpgradeCatalog
+	if *upgradesPath != "" {
+		upgrades, err = game.LoadUpgradeCatalog(*upgradesPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
+
+	var arenas *game.ArenaTable
+	if *arenasPath != "" {
+		arenas, err = game.LoadArenaTable(*arenasPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
+
+	var export *logExporter
+	if *logPath != "" {
+		export, err = newLogExporter(*logPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
+
+	// Seeds are drawn up front so results do not depend on how runs are scheduled
+	master := rand.New(rand.NewSource(*seed))
+	seeds := make([]int64, *runs)
+	for i := range seeds {
+		seeds[i] = master.Int63()
+	}
+
+	content := &game.GameHandler{Enemies: enemies, Upgrades: upgrades, Arenas: arenas}
+
+	results := make([]runResult, *runs)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(1, *workers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = simulateRun(i+1, seeds[i], content, *className, *patronName, policy, *maxTurns, *endless, export)
+			}
+		}()
+	}
+	for i := range seeds {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	if err := export.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error writing battle log:", err)
+		os.Exit(1)
+	}
+
+	printReport(results, *seed, *className, *policyName)
+}
+
+// simulateRun plays battles until the hero dies, beats the final boss or gets stuck in a stalemate.
+// In endless mode a won run goes on until the hero dies. Every run owns its hero and state,
+// so runs can be simulated concurrently.
+func simulateRun(run int, seed int64, content *game.GameHandler, className, patronName string, policy Policy, maxTurns int, endless bool, export *logExporter) runResult {
+	handler := &game.GameHandler{Enemies: content.Enemies, Upgrades: content.Upgrades, Arenas: content.Arenas}
+	hero := handler.NewHero("Simulant", className, patronName)
+	gameState := game.NewGameState(seed)
+	result := runResult{}
+
+	for {
+		enemy := handler.CreateEnemy(gameState.Rng, gameState.CurrentEnemy)
+		battle := handler.NewBattle(hero, enemy, gameState)
+		isOver := false
+		for !isOver && battle.Turn < maxTurns {
+			// A consumable or blessing queued before the hero's turn is used right away
+			if battle.HeroActsNext() {
+				useConsumables(handler, hero)
+				if hero.Blessing != nil && hero.Blessing.Ready() {
+					handler.InvokeBlessing()
+				}
+			}
+			isOver = battle.Step()
+		}
+
+		result.Battles++
+		result.Turns += battle.Turn
+		export.write(run, result.Battles, gameState.BattleLog)
+
+		if !isOver {
+			result.StalemateLevel = gameState.CurrentEnemy
+			return result
+		}
+
+		if hero.Health <= 0 {
+			result.DeathLevel = gameState.CurrentEnemy
+			// Elites carry their affixes in the name, the report groups deaths by roster entry
+			roster := handler.Enemies
+			if roster == nil {
+				roster = game.DefaultEnemyRoster()
+			}
+			result.DeathEnemy = roster.At(gameState.CurrentEnemy).Name
+			result.Depth = handler.EndlessDepth(gameState.CurrentEnemy)
+			return result
+		}
+		if gameState.GameOver {
+			result.Won = true
+			if !endless || !handler.ContinueEndless(hero, enemy, gameState) {
+				return result
+			}
+		}
+
+		// Loot is always worn, later drops come from stronger enemies
+		upgrades := []model.Upgrade{}
+		for _, upgrade := range gameState.Upgrades {
+			if upgrade.IsLoot() {
+				handler.HandleUpgrade(hero, upgrade)
+				continue
+			}
+			upgrades = append(upgrades, upgrade)
+		}
+
+		choice := policy(gameState.Rng, hero, upgrades)
+		handler.HandleUpgrade(hero, upgrades[choice])
+
+		// Prepare for next battle
+		gameState.CurrentEnemy++
+		gameState.UpgradeMode = false
+	}
+}
+
+// useConsumables drinks a Healing Draught when the hero is close to death
+func useConsumables(handler *game.GameHandler, hero *model.Player) {
+	if hero.Health*100 < hero.MaxHealth*35 && hero.Inventory[model.HealingDraught] > 0 {
+		handler.UseConsumable(model.HealingDraught)
+	}
+}
+
+// printReport writes the aggregated statistics of all runs to stdout
+func printReport(results []runResult, seed int64, className, policyName string) {
+	wins, stalemates, battles, turns := 0, 0, 0, 0
+	deaths := map[int]int{}
+	deathNames := map[int]string{}
+	maxLevel := 0
+	depths, bestDepth := 0, 0
+
+	for _, r := range results {
+		battles += r.Battles
+		turns += r.Turns
+		if r.Won {
+			wins++
+			depths += r.Depth
+			bestDepth = max(bestDepth, r.Depth)
+			continue
+		}
+		if r.StalemateLevel > 0 {
+			stalemates++
+			continue
+		}
+		deaths[r.DeathLevel]++
+		deathNames[r.DeathLevel] = r.DeathEnemy
+		maxLevel = max(maxLevel, r.DeathLevel)
+	}
+
+	fmt.Printf("Runs:         %d (seed %d, class %s, policy %s)\n", len(results), seed, className, policyName)
+	fmt.Printf("Win rate:     %.1f%% (%d/%d)\n", percent(wins, len(results)), wins, len(results))
+	if stalemates > 0 {
+		fmt.Printf("Stalemates:   %d (battle hit the turn cap)\n", stalemates)
+	}
+	if battles > 0 {
+		fmt.Printf("Turns/battle: %.2f\n", float64(turns)/float64(battles))
+	}
+	if bestDepth > 0 {
+		fmt.Printf("Endless:      depth %.1f on average, %d at best\n", float64(depths)/float64(wins), bestDepth)
+	}
+
+	fmt.Println()
+	fmt.Println("Deaths per enemy level:")
+	for level := 1; level <= maxLevel; level++ {
+		if deaths[level] == 0 {
+			continue
+		}
+		fmt.Printf("  %2d %-18s %6d  %5.1f%%\n", level, deathNames[level], deaths[level], percent(deaths[level], len(results)))
+	}
+}
+
+// percent returns part as a percentage of total
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// exportedEntry is a battle log entry tagged with the run and battle it belongs to
+type exportedEntry struct {
+	Run    int `json:"run"`
+	Battle int `json:"battle"`
+	model.LogEntry
+}
+
+// logExporter writes battle log entries as JSON lines, a nil exporter discards them.
+// Battles of concurrent runs are written whole but may interleave with each other.
+type logExporter struct {
+	mu   sync.Mutex
+	file *os.File
+	buf  *bufio.Writer
+	enc  *json.Encoder
+	err  error
+}
+
+// newLogExporter creates the file at path and prepares it for writing
+func newLogExporter(path string) (*logExporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(file)
+	return &logExporter{file: file, buf: buf, enc: json.NewEncoder(buf)}, nil
+}
+
+// write appends all entries of a battle, keeping the first error for Close
+func (e *logExporter) write(run, battle int, entries []model.LogEntry) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if e.err = e.enc.Encode(exportedEntry{Run: run, Battle: battle, LogEntry: entry}); e.err != nil {
+			return
+		}
+	}
+}
+
+// Close flushes the remaining entries and closes the file
+func (e *logExporter) Close() error {
+	if e == nil {
+		return nil
+	}
+	if err := e.buf.Flush(); err != nil && e.err == nil {
+		e.err = err
+	}
+	if err := e.file.Close(); err != nil && e.err == nil {
+		e.err = err
+	}
+	return e.err
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"gladiator-sim/game"
+)
+
+// TestParallelRunsAreDeterministic plays the same seeds one after another and in
+// parallel, the results must not depend on scheduling. Run it with -race as well.
+func TestParallelRunsAreDeterministic(t *testing.T) {
+	const runs = 16
+	seeds := make([]int64, runs)
+	for i := range seeds {
+		seeds[i] = int64(1000 + i)
+	}
+	content := &game.GameHandler{}
+	className := game.HeroClasses()[0].Name
+
+	simulate := func(i int) runResult {
+		// The blessing queue is exercised too, every run invokes it once it is ready
+		return simulateRun(i+1, seeds[i], content, className, "Mercury", randomPolicy, 1000, false, nil)
+	}
+
+	sequential := make([]runResult, runs)
+	for i := range seeds {
+		sequential[i] = simulate(i)
+	}
+
+	parallel := make([]runResult, runs)
+	var wg sync.WaitGroup
+	for i := range seeds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parallel[i] = simulate(i)
+		}()
+	}
+	wg.Wait()
+
+	for i := range seeds {
+		if sequential[i] != parallel[i] {
+			t.Errorf("seed %d: sequential run %+v, parallel run %+v", seeds[i], sequential[i], parallel[i])
+		}
+	}
+}
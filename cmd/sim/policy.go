@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	model "gladiator-sim/models"
+)
+
+// Policy picks which of the offered upgrades the simulated hero takes
+type Policy func(rng *rand.Rand, hero *model.Player, options []model.Upgrade) int
+
+// policies maps the names accepted by --policy to their implementation
+var policies = map[string]Policy{
+	"first":  firstPolicy,
+	"random": randomPolicy,
+	"heal":   healPolicy,
+}
+
+// firstPolicy always takes the first offered upgrade
+func firstPolicy(rng *rand.Rand, hero *model.Player, options []model.Upgrade) int {
+	return 0
+}
+
+// randomPolicy takes any offered upgrade with equal chance
+func randomPolicy(rng *rand.Rand, hero *model.Player, options []model.Upgrade) int {
+	return rng.Intn(len(options))
+}
+
+// healPolicy takes a Full Heal when below half health, otherwise picks randomly
+func healPolicy(rng *rand.Rand, hero *model.Player, options []model.Upgrade) int {
+	if hero.Health*2 < hero.MaxHealth {
+		for i, upgrade := range options {
+			if upgrade.Name == "Full Heal" {
+				return i
+			}
+		}
+	}
+	return randomPolicy(rng, hero, options)
+}
+
+// lookupPolicy returns the policy registered under name
+func lookupPolicy(name string) (Policy, error) {
+	policy, ok := policies[name]
+	if !ok {
+		names := make([]string, 0, len(policies))
+		for n := range policies {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown policy %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	return policy, nil
+}
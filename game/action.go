@@ -0,0 +1,85 @@
+package game
+
+import (
+	"fmt"
+
+	model "gladiator-sim/models"
+)
+
+const (
+	heavyDamage     = 160 // Percent of the rolled damage a Heavy Strike deals
+	heavyBlockBonus = 15  // Block chance the defender gains against a telegraphed Heavy Strike
+	tauntDamage     = 50  // Percent of the rolled damage a Taunt deals
+	defending       = "Defending"
+	taunted         = "Taunted"
+)
+
+// actionDamage scales rolled damage by the action it was rolled for
+func actionDamage(action model.Action, damage int) int {
+	switch action {
+	case model.ActionHeavyStrike:
+		return damage * heavyDamage / 100
+	case model.ActionTaunt:
+		return damage * tauntDamage / 100
+	default:
+		return damage
+	}
+}
+
+// prepareAction applies what an action does before any attack is made. Defending
+// cancels every attack of the turn, including extra ones granted by hooks.
+func (b *Battle) prepareAction(action model.Action, turn *TurnContext) {
+	owner, opponent := turn.Owner, turn.Opponent
+
+	switch action {
+	case model.ActionDefend:
+		turn.Attacks = 0
+		// Buffs tick at the end of the owner's turns, so the guard covers every attack the
+		// opponent makes until the owner's next turn is over
+		owner.AddBuff(model.Buff{
+			Name:   defending,
+			Source: defending,
+			Scope:  model.BuffTurns,
+			Turns:  2,
+			Mods:   model.Modifiers{Defense: 4, BlockChance: 35},
+		})
+		b.log(buffEntry(owner, fmt.Sprintf("%s raises the guard and waits for %s.", owner.Name, opponent.Name)))
+
+	case model.ActionTaunt:
+		// The opponent's buffs tick at the end of its own turns, so it stays reckless for its
+		// next two turns, however many turns the owner gets in between
+		opponent.AddBuff(model.Buff{
+			Name:   taunted,
+			Source: taunted,
+			Scope:  model.BuffTurns,
+			Turns:  2,
+			Mods:   model.Modifiers{AttackMin: 2, AttackMax: 2, Defense: -4, BlockChance: -10},
+		})
+		b.log(buffEntry(owner, fmt.Sprintf("%s taunts %s, who charges in recklessly!", owner.Name, opponent.Name)))
+	}
+}
+
+// ChooseAction hands the hero's action to the battle. It is safe to call from the
+// input goroutine, only the first choice made per turn counts.
+func (h *GameHandler) ChooseAction(action model.Action) {
+	select {
+	case h.choiceQueue() <- action:
+	default:
+	}
+}
+
+// choiceQueue returns the channel tactical battles receive the hero's actions from
+func (h *GameHandler) choiceQueue() chan model.Action {
+	h.choicesOnce.Do(func() {
+		h.choices = make(chan model.Action, 1)
+	})
+	return h.choices
+}
+
+// clearChoice drops a choice left over from a turn that was already decided
+func (h *GameHandler) clearChoice() {
+	select {
+	case <-h.choiceQueue():
+	default:
+	}
+}
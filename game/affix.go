@@ -0,0 +1,116 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	model "gladiator-sim/models"
+)
+
+// Affix is a trait that makes an enemy tougher than its archetype
+type Affix struct {
+	Name        string
+	Description string // Completes "This one ..."
+	Apply       func(p *model.Player)
+}
+
+// Names of the hooks granted by affixes
+const (
+	enraged      = "Enraged"
+	enragedBelow = 30 // Percent of max health below which an enraged enemy hits harder
+)
+
+const (
+	eliteChance     = 12 // Percent chance a regular enemy past the first level is an elite
+	eliteTwoAffixes = 8  // Level from which elites may roll a second affix
+)
+
+var affixes = []Affix{
+	{
+		Name:        "Vampiric",
+		Description: "drains life with every blow",
+		Apply: func(p *model.Player) {
+			p.LifeSteal += 15
+		},
+	},
+	{
+		Name:        "Armored",
+		Description: "is clad in heavy plate",
+		Apply: func(p *model.Player) {
+			p.Defense += 3 + p.Defense/2
+		},
+	},
+	{
+		Name:        enraged,
+		Description: fmt.Sprintf("flies into a rage below %d%% health", enragedBelow),
+		Apply: func(p *model.Player) {
+			p.AddHook(enraged)
+		},
+	},
+	{
+		Name:        "Thorned",
+		Description: "is covered in barbs that cut attackers",
+		Apply: func(p *model.Player) {
+			p.AddHook(spikedArmor)
+		},
+	},
+	{
+		Name:        "Regenerating",
+		Description: "mends its wounds whenever it is hit",
+		Apply: func(p *model.Player) {
+			p.Regeneration += 5
+		},
+	},
+}
+
+func init() {
+	// Half again as much damage while badly wounded
+	RegisterHook(enraged, HookFuncs{Hit: func(ctx *HitContext) {
+		if !ctx.IsAttacker() || ctx.Owner.Health*100 >= ctx.Owner.MaxHealth*enragedBelow {
+			return
+		}
+		ctx.Damage += ctx.Damage / 2
+		ctx.Log(buffEntry(ctx.Owner, fmt.Sprintf("%s is enraged!", ctx.Owner.Name)))
+	}})
+}
+
+// rollElite sometimes turns a regular enemy into an elite with one or two affixes
+func rollElite(rng *rand.Rand, enemy *model.Player, level int) {
+	if level <= 1 || rng.Intn(100) >= eliteChance {
+		return
+	}
+	n := 1
+	if level >= eliteTwoAffixes && rng.Intn(2) == 0 {
+		n++
+	}
+	applyAffixes(rng, enemy, n)
+	enemy.Elite = true
+}
+
+// applyAffixes gives the enemy n different random affixes and names them in its name and description
+func applyAffixes(rng *rand.Rand, enemy *model.Player, n int) {
+	n = min(n, len(affixes))
+	if n < 1 {
+		return
+	}
+
+	descriptions := make([]string, 0, n)
+	for _, i := range rng.Perm(len(affixes))[:n] {
+		affix := affixes[i]
+		affix.Apply(enemy)
+		enemy.Affixes = append(enemy.Affixes, affix.Name)
+		descriptions = append(descriptions, affix.Description)
+	}
+
+	enemy.Name = strings.Join(enemy.Affixes, " ") + " " + enemy.Name
+	enemy.Description = strings.TrimSpace(enemy.Description + " This one " + joinWithAnd(descriptions) + ".")
+}
+
+// joinWithAnd joins phrases into a readable list: "a", "a and b", "a, b and c"
+func joinWithAnd(phrases []string) string {
+	if len(phrases) < 2 {
+		return strings.Join(phrases, "")
+	}
+	return strings.Join(phrases[:len(phrases)-1], ", ") + " and " + phrases[len(phrases)-1]
+}
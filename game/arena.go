@@ -0,0 +1,150 @@
+package game
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+
+	model "gladiator-sim/models"
+)
+
+// defaultArenasJSON is the table of arena conditions the game ships with
+//
+//go:embed content/arenas.json
+var defaultArenasJSON []byte
+
+// ArenaTable holds the conditions a battle can be fought under, rolled by weight
+type ArenaTable struct {
+	Conditions []model.ArenaCondition `json:"conditions"`
+}
+
+// Roll picks a condition, each with a chance proportional to its weight
+func (t *ArenaTable) Roll(rng *rand.Rand) model.ArenaCondition {
+	total := 0
+	for _, condition := range t.Conditions {
+		total += condition.Weight
+	}
+
+	r := rng.Intn(total)
+	for _, condition := range t.Conditions {
+		if r < condition.Weight {
+			return condition
+		}
+		r -= condition.Weight
+	}
+	return t.Conditions[len(t.Conditions)-1]
+}
+
+var (
+	defaultArenas     *ArenaTable
+	defaultArenasOnce sync.Once
+)
+
+// DefaultArenaTable returns the embedded table of arena conditions
+func DefaultArenaTable() *ArenaTable {
+	defaultArenasOnce.Do(func() {
+		table, err := ParseArenaTable(defaultArenasJSON)
+		if err != nil {
+			panic(fmt.Sprintf("embedded arena table is invalid: %v", err))
+		}
+		defaultArenas = table
+	})
+	return defaultArenas
+}
+
+// LoadArenaTable reads and validates an arena table file
+func LoadArenaTable(path string) (*ArenaTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading arena table: %w", err)
+	}
+	table, err := ParseArenaTable(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return table, nil
+}
+
+// ParseArenaTable decodes and validates an arena table from JSON
+func ParseArenaTable(data []byte) (*ArenaTable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Typos in field names would silently fall back to zero otherwise
+	decoder.DisallowUnknownFields()
+
+	var table ArenaTable
+	if err := decoder.Decode(&table); err != nil {
+		return nil, fmt.Errorf("decoding arena table: %w", err)
+	}
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// validate checks that every condition can be rolled and stays within sane ranges
+func (t *ArenaTable) validate() error {
+	if len(t.Conditions) == 0 {
+		return errors.New("arena table needs at least one condition")
+	}
+
+	names := map[string]bool{}
+	for _, condition := range t.Conditions {
+		if condition.Name == "" {
+			return errors.New("arena condition without a name")
+		}
+		if names[condition.Name] {
+			return fmt.Errorf("arena condition %q is defined twice", condition.Name)
+		}
+		names[condition.Name] = true
+
+		if condition.Weight < 1 {
+			return fmt.Errorf("arena condition %q: weight must be at least 1, got %d", condition.Name, condition.Weight)
+		}
+
+		chances := []struct {
+			name  string
+			value int
+		}{
+			{"crit_chance", condition.CritChance},
+			{"block_chance", condition.BlockChance},
+			{"evasion", condition.Evasion},
+		}
+		for _, c := range chances {
+			if c.value < -100 || c.value > 100 {
+				return fmt.Errorf("arena condition %q: %s must be between -100 and 100, got %d", condition.Name, c.name, c.value)
+			}
+		}
+
+		healingChanges := []struct {
+			name  string
+			value int
+		}{
+			{"regeneration_percent", condition.RegenerationPercent},
+			{"life_steal_percent", condition.LifeStealPercent},
+		}
+		for _, h := range healingChanges {
+			if h.value < -100 {
+				return fmt.Errorf("arena condition %q: %s must be at least -100, got %d", condition.Name, h.name, h.value)
+			}
+		}
+	}
+	return nil
+}
+
+// arenas returns the handler's arena table, falling back to the embedded one
+func (h *GameHandler) arenas() *ArenaTable {
+	if h.Arenas != nil {
+		return h.Arenas
+	}
+	return DefaultArenaTable()
+}
+
+// percentChange applies a change in percent to value, e.g. -50 halves it
+func percentChange(value, percent int) int {
+	return value * (100 + percent) / 100
+}
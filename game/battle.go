@@ -3,6 +3,8 @@ package game
 import (
 	"fmt"
 	"math/rand"
+	"strings"
+	"sync"
 	"time"
 
 	model "gladiator-sim/models"
@@ -12,36 +14,85 @@ import (
 )
 
 // GameHandler implements the ui.InputHandler interface
-type GameHandler struct{}
+type GameHandler struct {
+	SavePath string          // Where runs are saved automatically, empty disables saving
+	Enemies  *EnemyRoster    // Enemies of a run, nil uses the embedded roster
+	Upgrades *UpgradeCatalog // Upgrades offered during a run, nil uses the embedded catalog
+	Arenas   *ArenaTable     // Conditions battles are fought under, nil uses the embedded table
+	// Gold and unlocks kept across runs, nil makes everything available without earning gold
+	Profile     *Profile
+	ProfilePath string // Where the profile is saved, empty keeps it in memory only
+
+	actions       chan model.Consumable // Consumables the hero uses on its next turn
+	actionsOnce   sync.Once
+	choices       chan model.Action // Actions of the hero in tactical mode
+	choicesOnce   sync.Once
+	blessings     chan struct{} // Blessing invoked for the hero's next turn
+	blessingsOnce sync.Once
+}
 
 // TurnDelay is the delay between battle turns
 const TurnDelay = 800 * time.Millisecond
 
-func RandRange(min, max int) int {
-	return rand.Intn(max-min+1) + min
-}
+// maxEvasion caps the chance to dodge so no one becomes untouchable
+const maxEvasion = 75
 
-// CalculateDamage determines attack damage with critical hits and blocks
-func CalculateDamage(attacker, defender *model.Player) model.BattleResult {
-	damage := RandRange(attacker.AttackMin, attacker.AttackMax)
+// turnsCounter counts the turns each combatant started in the current battle
+const turnsCounter = "turns"
 
-	critChance := model.CriticalChance
-	if attacker.CritChance > 0 {
-		critChance = attacker.CritChance
+// woundCounter keeps the most health each combatant was missing at any point of the battle
+const woundCounter = "deepest wound"
+
+// RandRange returns a random number between min and max (inclusive)
+func RandRange(rng *rand.Rand, min, max int) int {
+	// Upgrades like "I'm Feeling Lucky" can push the minimum past the maximum
+	if max <= min {
+		return min
 	}
+	return rng.Intn(max-min+1) + min
+}
 
-	blockChance := model.BlockChance
-	if defender.BlockChance > 0 {
-		blockChance = defender.BlockChance
+// CalculateDamage determines attack damage with dodges, critical hits and blocks.
+// Stats are read with all active buffs and the arena condition applied, one-time
+// buffs are consumed and the hooks of both combatants may change the outcome along the way.
+func CalculateDamage(rng *rand.Rand, attacker, defender *model.Player, action model.Action, arena model.ArenaCondition) model.BattleResult {
+	atk, def := attacker.Effective(), defender.Effective()
+
+	damage := actionDamage(action, RandRange(rng, atk.AttackMin, atk.AttackMax))
+
+	// A dodged attack misses completely, so none of the on-hit effects happen.
+	// Only evasive defenders roll, keeping the random stream of everyone else as it was.
+	if evasion := def.Evasion + arena.Evasion; evasion > 0 && rng.Intn(100) < min(evasion, maxEvasion) {
+		attacker.ConsumeAttackBuffs()
+		return model.BattleResult{
+			Attacker: attacker,
+			Defender: defender,
+			IsDodged: true,
+			Action:   action,
+		}
 	}
 
-	isCritical := rand.Intn(100) < critChance
-	isBlocked := rand.Intn(100) < blockChance
+	// Players without a chance of their own use the default one. Buffs and the arena only
+	// apply on top of it, so lowering a chance never falls back to the higher default.
+	critChance := chanceOrDefault(attacker.CritChance, model.CriticalChance)
+	critChance = max(0, critChance+atk.CritChance-attacker.CritChance+arena.CritChance)
+
+	blockChance := chanceOrDefault(defender.BlockChance, model.BlockChance)
+	if action == model.ActionHeavyStrike {
+		blockChance += heavyBlockBonus
+	}
+	blockChance = max(0, blockChance+def.BlockChance-defender.BlockChance+arena.BlockChance)
+
+	isCritical := rng.Intn(100) < critChance
+	for rerolls := attacker.Modifiers().CritRerolls; !isCritical && rerolls > 0; rerolls-- {
+		isCritical = rng.Intn(100) < critChance
+	}
+	isBlocked := rng.Intn(100) < blockChance
 
 	if isCritical {
 		critMultiplier := 2.0
-		if attacker.CritDamage > 0 {
-			critMultiplier = 2.0 + (float64(attacker.CritDamage) / 100.0)
+		if atk.CritDamage > 0 {
+			critMultiplier = 2.0 + (float64(atk.CritDamage) / 100.0)
 		}
 		damage = int(float64(damage) * critMultiplier)
 	}
@@ -50,69 +101,116 @@ func CalculateDamage(attacker, defender *model.Player) model.BattleResult {
 		damage /= 2
 	}
 
-	damage -= defender.Defense
+	// Defense only stops weapon damage, resistances apply to every type
+	damageTypes := []model.DamageType{atk.AttackType()}
+	defended := max(0, min(def.Defense, damage))
+	damage = resist(max(0, damage-def.Defense), &def, atk.AttackType())
+	for _, damageType := range model.DamageTypes {
+		if extra := atk.ExtraDamage.Get(damageType); extra > 0 {
+			damage += resist(extra, &def, damageType)
+			damageTypes = append(damageTypes, damageType)
+		}
+	}
 	if damage < 1 {
+		// The minimum damage already makes up for a point of what defense stopped
+		defended = max(0, defended-(1-damage))
 		damage = 1
 	}
 
-	defender.Health -= damage
-
-	if attacker.LifeSteal > 0 {
-		healAmount := int(float64(damage) * float64(attacker.LifeSteal) / 100.0)
-		if healAmount > 0 {
-			attacker.Health += healAmount
-			if attacker.Health > attacker.MaxHealth {
-				attacker.Health = attacker.MaxHealth
-			}
-		}
+	ctx := &HitContext{
+		Rng:        rng,
+		Attacker:   attacker,
+		Defender:   defender,
+		Damage:     damage,
+		IsCritical: isCritical,
+		IsBlocked:  isBlocked,
+		Defended:   defended,
+		Arena:      arena,
 	}
 
-	regen := 0
-	if defender.Regeneration > 0 {
-		healAmount := int(float64(defender.MaxHealth) * float64(defender.Regeneration) / 100.0)
-		regen = healAmount
-		if healAmount > 0 {
-			defender.Health += healAmount
-			if defender.Health > defender.MaxHealth {
-				defender.Health = defender.MaxHealth
-			}
-		}
+	if isCritical {
+		runHitHooks(ctx, Hook.OnCrit, attacker, defender)
 	}
-
-	isGameOver := defender.Health <= 0
-
-	if defender.Health < 0 {
-		defender.Health = 0
+	if isBlocked {
+		runHitHooks(ctx, Hook.OnBlock, attacker, defender)
+	}
+	runHitHooks(ctx, Hook.OnHit, attacker, defender)
+
+	ctx.applyDamage()
+	attacker.ConsumeAttackBuffs()
+
+	// The blow lands first, so reflected damage can only finish the attacker
+	// when the defender is still standing
+	winner := resolveDeath(ctx, attacker, defender)
+	if winner == nil {
+		winner = resolveDeath(ctx, defender, attacker)
+	} else if attacker.Health < 1 {
+		attacker.Health = 1
 	}
 
+	isGameOver := winner != nil
+
 	var winnerName string
 	if isGameOver {
-		winnerName = attacker.Name
-		attacker.Wins++
-		if attacker.LifeOnKill > 0 {
-			attacker.Health += attacker.LifeOnKill
-			if attacker.Health > attacker.MaxHealth {
-				attacker.Health = attacker.MaxHealth
-			}
-		}
+		winnerName = winner.Name
 	}
 
 	return model.BattleResult{
-		Attacker:     attacker,
-		Defender:     defender,
-		Damage:       damage,
-		IsCritical:   isCritical,
-		IsBlocked:    isBlocked,
-		IsGameOver:   isGameOver,
-		WinnerName:   winnerName,
-		Regeneration: regen,
+		Attacker:   attacker,
+		Defender:   defender,
+		Damage:     ctx.Damage,
+		IsCritical: isCritical,
+		IsBlocked:  isBlocked,
+		IsGameOver: isGameOver,
+		WinnerName: winnerName,
+		Events:     ctx.Events,
+		Action:     action,
+		Types:      damageTypes,
+	}
+}
+
+// chanceOrDefault returns a base chance, or the default for players without their own
+func chanceOrDefault(chance, fallback int) int {
+	if chance > 0 {
+		return chance
 	}
+	return fallback
+}
+
+// resolveDeath checks whether victim died to killer, giving the victim's hooks a
+// chance to prevent it, and returns the killer when the victim stays dead
+func resolveDeath(ctx *HitContext, killer, victim *model.Player) *model.Player {
+	if victim.Health > 0 {
+		return nil
+	}
+
+	ctx.Prevented = false
+	runHitHooks(ctx, Hook.OnDeath, victim)
+	if ctx.Prevented && victim.Health > 0 {
+		return nil
+	}
+
+	victim.Health = 0
+	killer.Wins++
+	runHitHooks(ctx, Hook.OnKill, killer)
+	return killer
 }
 
 // FormatBattleMessage creates a descriptive message for the battle log
 func FormatBattleMessage(result model.BattleResult) string {
-	msg := fmt.Sprintf("%s strikes %s for %d damage!",
-		result.Attacker.Name, result.Defender.Name, result.Damage)
+	if result.IsDodged {
+		return fmt.Sprintf("%s strikes at %s, but %s dodges! 💨", result.Attacker.Name, result.Defender.Name, result.Defender.Name)
+	}
+
+	verb := "strikes"
+	switch result.Action {
+	case model.ActionHeavyStrike:
+		verb = "brings down a heavy strike on"
+	case model.ActionTaunt:
+		verb = "jabs"
+	}
+	msg := fmt.Sprintf("%s %s %s for %d %s damage!",
+		result.Attacker.Name, verb, result.Defender.Name, result.Damage, damageTypeText(result.Types))
 
 	if result.IsCritical {
 		msg += fmt.Sprintf(" 󰓥 %s!", model.CriticalHit)
@@ -123,78 +221,354 @@ func FormatBattleMessage(result model.BattleResult) string {
 	return msg
 }
 
-// StartBattle handles the battle loop between two players
-func (h *GameHandler) StartBattle(hero, enemy *model.Player, screen tcell.Screen, gameState *model.GameState, quit chan bool, done chan bool) {
-	gameState.BattleLog = []string{
-		"🔥GLADIATOR BATTLE🔥",
-		fmt.Sprintf("%s vs %s", hero.Name, enemy.Name),
-		"",
+// NewAttackEntry creates the battle log entry describing an attack
+func NewAttackEntry(result model.BattleResult) model.LogEntry {
+	kind := model.LogHit
+	switch {
+	case result.IsDodged:
+		kind = model.LogDodge
+	case result.IsCritical:
+		kind = model.LogCrit
+	case result.IsBlocked:
+		kind = model.LogBlock
+	}
+
+	return model.LogEntry{
+		Kind:   kind,
+		Actor:  result.Attacker.Name,
+		Target: result.Defender.Name,
+		Amount: result.Damage,
+		Text:   FormatBattleMessage(result),
+		Types:  result.Types,
+	}
+}
+
+// Battle is a single fight between the hero and an enemy, independent of any UI
+type Battle struct {
+	Hero  *model.Player
+	Enemy *model.Player
+	State *model.GameState
+	Turn  int // Number of attacks made so far
+	// What the hero does on its next turn, reset to a regular attack once used
+	NextAction model.Action
+
+	// Progress of each combatant towards its next turn, see advanceTimeline
+	heroInitiative  int
+	enemyInitiative int
+
+	handler *GameHandler
+}
+
+// NewBattle prepares a battle and writes its introduction to the battle log
+func (h *GameHandler) NewBattle(hero, enemy *model.Player, gameState *model.GameState) *Battle {
+	gameState.BattleLog = []model.LogEntry{
+		model.Narration("🔥GLADIATOR BATTLE🔥"),
+		model.Narration(fmt.Sprintf("%s vs %s", hero.Name, enemy.Name)),
+		model.Narration(""),
 	}
 
 	if enemy.Description != "" {
-		gameState.BattleLog = append(gameState.BattleLog, enemy.Description)
+		gameState.Narrate(enemy.Description)
+	}
+
+	if enemy.IsElite() {
+		gameState.AddToBattleLog(model.LogEntry{
+			Kind:  model.LogBuff,
+			Actor: enemy.Name,
+			Text:  fmt.Sprintf("⭐ ELITE: %s. Defeat it for a better reward!", strings.Join(enemy.Affixes, ", ")),
+		})
+	}
+
+	// Every battle is fought under a new condition
+	gameState.Arena = h.arenas().Roll(gameState.Rng)
+	gameState.Narrate(fmt.Sprintf("Arena: %s. %s.", gameState.Arena.Name, gameState.Arena.Description))
+	gameState.Demand = rollDemand(gameState.Rng)
+	gameState.Narrate(fmt.Sprintf("📣 The crowd demands: %s!", gameState.Demand.Text))
+
+	gameState.Narrate("")
+
+	h.clearActions()
+	h.clearBlessing()
+
+	return &Battle{
+		Hero:    hero,
+		Enemy:   enemy,
+		State:   gameState,
+		handler: h,
 	}
+}
+
+// log adds an entry to the battle log, stamped with the current turn
+func (b *Battle) log(entry model.LogEntry) {
+	entry.Turn = b.Turn
+	b.State.AddToBattleLog(entry)
+}
 
-	gameState.BattleLog = append(gameState.BattleLog, "")
+// narrate adds a plain narration line to the battle log
+func (b *Battle) narrate(message string) {
+	b.log(model.Narration(message))
+}
+
+// logExpired notes in the battle log that a buff has worn off
+func (b *Battle) logExpired(owner *model.Player, buff model.Buff) {
+	b.log(model.LogEntry{
+		Kind:  model.LogBuff,
+		Actor: owner.Name,
+		Text:  fmt.Sprintf("%s's %s wears off.", owner.Name, buff.Name),
+	})
+}
+
+// endBattle expires every buff, status and counter that only lasts for the battle
+func (b *Battle) endBattle() {
+	for _, p := range []*model.Player{b.Hero, b.Enemy} {
+		p.EndBattleBuffs()
+		p.ClearStatuses()
+		p.ResetCounters()
+	}
+	// Blessings charge anew in every battle
+	if b.Hero.Blessing != nil {
+		b.Hero.Blessing.Charge = 0
+	}
+}
+
+// offerUpgrades enters upgrade mode after a victory, elites reward an extra and rarer choice
+// and a full crowd favor meter is spent on rarer choices
+func (b *Battle) offerUpgrades() {
+	state := b.State
+	state.UpgradeMode = true
+	if !b.Enemy.IsElite() {
+		state.Upgrades = b.handler.createUpgrades(state.Rng, b.Hero, upgradeChoices, b.spendFavor())
+		return
+	}
+	// The elite already brings rarer choices, the favor is kept for later
+	state.Upgrades = b.handler.createUpgrades(state.Rng, b.Hero, upgradeChoices+1, true)
+	b.log(model.LogEntry{
+		Kind:  model.LogUpgrade,
+		Actor: b.Hero.Name,
+		Text:  "⭐ The elite's defeat earns you an extra, rarer choice!",
+	})
+}
+
+// HeroActsNext reports whether the next step is the hero's turn
+func (b *Battle) HeroActsNext() bool {
+	actor, _ := b.nextActor()
+	return actor == b.Hero
+}
+
+// Step plays a single attack and reports whether the battle is over
+func (b *Battle) Step() bool {
+	hero, enemy, gameState := b.Hero, b.Enemy, b.State
+
+	// Faster combatants may take several turns in a row
+	attacker, defender := hero, enemy
+	if b.advanceTimeline() == enemy {
+		attacker, defender = enemy, hero
+	}
+	b.Turn++
+
+	// Statuses take effect first, a stunned combatant only waits for them to wear off
+	skipTurn, isGameOver := b.tickStatuses(attacker, defender)
+	if !skipTurn {
+		isGameOver = b.takeTurn(attacker, defender)
+	}
+
+	for _, buff := range attacker.TickBuffs() {
+		b.logExpired(attacker, buff)
+	}
+
+	if !isGameOver {
+		return false
+	}
+
+	b.endBattle()
+
+	b.narrate("")
+
+	if hero.Health <= 0 {
+		// Hero lost
+		b.log(model.LogEntry{
+			Kind:   model.LogDefeat,
+			Actor:  enemy.Name,
+			Target: hero.Name,
+			Text:   fmt.Sprintf("💀 %s has fallen! GAME OVER 💀", hero.Name),
+		})
+		b.narrate(fmt.Sprintf("Final Score: %d victories", hero.Wins))
+		if gameState.Endless {
+			b.log(b.handler.recordDepth(gameState, hero, b.handler.EndlessDepth(gameState.CurrentEnemy)))
+		}
+		if b.handler.Profile != nil {
+			b.narrate(fmt.Sprintf("Gold earned: %d. Press 'r' to spend it in the shop.", gameState.GoldEarned))
+		}
+		b.narrate(fmt.Sprintf("Seed: %d", gameState.Seed))
+		gameState.GameOver = true
+		b.handler.clearSave(gameState)
+		return true
+	}
+
+	// Hero won
+	b.log(model.LogEntry{
+		Kind:   model.LogVictory,
+		Actor:  hero.Name,
+		Target: enemy.Name,
+		Text:   fmt.Sprintf("🏆 %s is %s! 🏆", hero.Name, model.Victorious),
+	})
+
+	roster := b.handler.roster()
+
+	if b.handler.Profile != nil {
+		// Endless levels lie past the bosses, their gold only grows with the level
+		isBoss := !gameState.Endless && roster.IsBossLevel(gameState.CurrentEnemy)
+		b.log(b.handler.awardGold(gameState, hero, goldForWin(gameState.CurrentEnemy, isBoss)))
+	}
+	b.judgeDemand()
+
+	switch {
+	// Endless mode goes on until the hero dies
+	case gameState.Endless:
+		b.narrate(fmt.Sprintf("Depth %d cleared! An even stronger challenger awaits.", b.handler.EndlessDepth(gameState.CurrentEnemy)))
+		b.offerUpgrades()
+
+	case hero.Wins >= roster.Levels():
+		b.log(model.LogEntry{Kind: model.LogVictory, Actor: hero.Name, Text: fmt.Sprintf("🎉 LEGENDARY VICTORY! You've defeated %s! 🎉", enemy.Name)})
+		b.log(model.LogEntry{Kind: model.LogVictory, Actor: hero.Name, Text: "🏆 Your name will be remembered for eternity! 🏆"})
+		b.narrate("Press 'e' to keep fighting in endless mode.")
+		gameState.GameOver = true
+		b.handler.clearSave(gameState)
+		return true
+
+	// Prepare for the first boss battle
+	case hero.Wins == len(roster.Enemies):
+		b.narrate(fmt.Sprintf("You've defeated all champions! Now face %s!", strings.ToUpper(roster.At(hero.Wins+1).Name)))
+		b.offerUpgrades()
+
+	// Prepare for the next boss battle
+	case roster.IsBossLevel(hero.Wins + 1):
+		b.narrate(fmt.Sprintf("Another legend falls! Now face %s!", strings.ToUpper(roster.At(hero.Wins+1).Name)))
+		b.offerUpgrades()
+
+	// Otherwise, prepare for next battle
+	default:
+		b.narrate("Choose an upgrade to continue your journey!")
+		b.offerUpgrades()
+	}
+
+	if found, ok := findConsumable(gameState.Rng, hero); ok {
+		b.log(model.LogEntry{
+			Kind:  model.LogUpgrade,
+			Actor: hero.Name,
+			Text:  fmt.Sprintf("You found a %s among the spoils!", found),
+		})
+	}
+
+	// Loot is offered on top of the upgrades, equipping it does not use up the choice
+	gameState.Upgrades = append(b.handler.lootOffers(gameState.Rng, hero, gameState.CurrentEnemy), gameState.Upgrades...)
+	// The number of choices varies with the loot, so the selection starts over
+	gameState.SelectedUpgrade = 0
+
+	// Entering upgrade mode is a safe point to resume the run from
+	b.handler.autoSave(hero, enemy, gameState)
+	return true
+}
+
+// takeTurn lets the attacker act and reports whether the battle is over.
+// Consumables picked during the last turn take effect now, enemies always attack.
+func (b *Battle) takeTurn(attacker, defender *model.Player) bool {
+	gameState := b.State
+
+	action := model.ActionAttack
+	if attacker == b.Hero {
+		b.useQueued()
+		b.useBlessing()
+		action, b.NextAction = b.NextAction, model.ActionAttack
+	}
+
+	turn := &TurnContext{
+		Rng:       gameState.Rng,
+		Owner:     attacker,
+		Opponent:  defender,
+		Turn:      b.Turn,
+		OwnerTurn: attacker.IncrementCounter(turnsCounter),
+		Attacks:   1,
+	}
+	for _, hook := range hooksFor(attacker) {
+		hook.OnTurnStart(turn)
+	}
+	for _, entry := range turn.Events {
+		b.log(entry)
+	}
+	b.prepareAction(action, turn)
+
+	isGameOver := false
+	for i := 0; i < turn.Attacks && !isGameOver; i++ {
+		result := CalculateDamage(gameState.Rng, attacker, defender, action, gameState.Arena)
+		b.log(NewAttackEntry(result))
+		b.trackDemand(result)
+		for _, entry := range result.Events {
+			b.log(entry)
+		}
+		// Reflected damage can push the attacker past a threshold as well
+		for _, p := range []*model.Player{defender, attacker} {
+			for _, entry := range enterPhases(p) {
+				b.log(entry)
+			}
+		}
+		isGameOver = result.IsGameOver
+	}
+
+	// Winding up a heavy blow leaves the attacker behind on the timeline
+	if action == model.ActionHeavyStrike {
+		b.delay(attacker, heavyStrikeDelay)
+	}
+	if attacker == b.Hero {
+		b.chargeBlessing()
+	}
+	return isGameOver
+}
+
+// awaitChoice waits for the player to pick the hero's action. A blessing invoked
+// meanwhile is carried out right away, so its effect shows before the choice is made.
+func (h *GameHandler) awaitChoice(battle *Battle, screen tcell.Screen) model.Action {
+	for {
+		select {
+		case action := <-h.choiceQueue():
+			return action
+		case <-h.blessingQueue():
+			battle.bless()
+			ui.DrawUI(screen, battle.Hero, battle.Enemy, battle.State)
+		}
+	}
+}
+
+// StartBattle runs a battle in the background, drawing every turn to the screen
+func (h *GameHandler) StartBattle(hero, enemy *model.Player, screen tcell.Screen, gameState *model.GameState, quit chan bool, done chan bool) {
+	battle := h.NewBattle(hero, enemy, gameState)
 
 	ui.DrawUI(screen, hero, enemy, gameState)
 	time.Sleep(TurnDelay)
 
 	go func() {
-		turn := 0
-
-		for hero.Health > 0 && enemy.Health > 0 {
+		for {
 			select {
 			case <-quit:
 				return // Exit if user presses 'q'
 			default:
-				// Determine attacker and defender based on turn
-				attacker, defender := hero, enemy
-				if turn%2 == 1 {
-					attacker, defender = enemy, hero
+				// In tactical mode the hero's turn waits for the player's choice, unless it is lost to a stun
+				if gameState.Tactical && battle.HeroActsNext() && !hero.HasStatus(model.StatusStun) {
+					h.clearChoice()
+					gameState.AwaitingAction = true
+					ui.DrawUI(screen, hero, enemy, gameState)
+					battle.NextAction = h.awaitChoice(battle, screen)
+					gameState.AwaitingAction = false
 				}
 
-				result := CalculateDamage(attacker, defender)
-				gameState.AddToBattleLog(FormatBattleMessage(result))
-
-				if result.IsGameOver {
-					gameState.AddToBattleLog("")
-
-					if defender.IsHero {
-						// Hero lost
-						gameState.AddToBattleLog(fmt.Sprintf("💀 %s has fallen! GAME OVER 💀", hero.Name))
-						gameState.AddToBattleLog(fmt.Sprintf("Final Score: %d victories", hero.Wins))
-						gameState.GameOver = true
-					} else {
-						// Hero won
-						gameState.AddToBattleLog(fmt.Sprintf("🏆 %s is %s! 🏆", hero.Name, model.Victorious))
-
-						if hero.Wins >= len(enemyTypes)+1 {
-							gameState.AddToBattleLog("🎉 LEGENDARY VICTORY! You've defeated The Immortal! 🎉")
-							gameState.AddToBattleLog("🏆 Your name will be remembered for eternity! 🏆")
-							gameState.GameOver = true
-
-							// Prepare for final battle
-						} else if hero.Wins == len(enemyTypes) {
-							gameState.AddToBattleLog("You've defeated all champions! Now face THE IMMORTAL!")
-							gameState.UpgradeMode = true
-							gameState.Upgrades = CreateUpgrades(hero)
-
-							// Otherwise, prepare for next battle
-						} else {
-							gameState.AddToBattleLog("Choose an upgrade to continue your journey!")
-							gameState.UpgradeMode = true
-							gameState.Upgrades = CreateUpgrades(hero)
-						}
-					}
+				isOver := battle.Step()
+				ui.DrawUI(screen, hero, enemy, gameState)
 
-					ui.DrawUI(screen, hero, enemy, gameState)
+				if isOver {
 					done <- true
 					return
 				}
 
-				turn++
-				ui.DrawUI(screen, hero, enemy, gameState)
 				time.Sleep(TurnDelay)
 			}
 		}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"fmt"
+
+	model "gladiator-sim/models"
+)
+
+// HeroClass is a gladiator type the hero can start a run as
+type HeroClass struct {
+	Name        string
+	Description string
+	Passive     string       // Name of the signature passive, registered as a hook
+	PassiveText string       // What the passive does
+	Stats       model.Player // Starting stats of the class
+	UnlockPrice int          // Gold needed to unlock it in the shop, 0 means always available
+}
+
+// Names of the class passives
+const (
+	scutumWall    = "Scutum Wall"
+	netThrow      = "Net Throw"
+	relentless    = "Relentless Pursuit"
+	curvedBlade   = "Curved Blade"
+	netThrowTurns = 3
+)
+
+// heroClasses are all classes, the first one is the default
+var heroClasses = []HeroClass{
+	{
+		Name:        "Murmillo",
+		Description: "Heavily armored fighter behind a large scutum",
+		Passive:     scutumWall,
+		PassiveText: "Blocked attacks deal only a quarter of their damage",
+		Stats: model.Player{
+			Health:      150,
+			MaxHealth:   150,
+			AttackMin:   10,
+			AttackMax:   14,
+			Defense:     3,
+			CritChance:  8,
+			BlockChance: 18,
+			Speed:       10,
+		},
+	},
+	{
+		Name:        "Retiarius",
+		Description: "Nimble net fighter armed with a trident",
+		Passive:     netThrow,
+		UnlockPrice: 200,
+		PassiveText: fmt.Sprintf("Opens every battle by netting the opponent: -15%% block and -3 defense for %d turns", netThrowTurns),
+		Stats: model.Player{
+			Health:      120,
+			MaxHealth:   120,
+			AttackMin:   10,
+			AttackMax:   18,
+			Defense:     1,
+			CritChance:  15,
+			BlockChance: 8,
+			Speed:       12,
+			DamageType:  model.DamagePiercing,
+		},
+	},
+	{
+		Name:        "Secutor",
+		Description: "Relentless chaser who wears opponents down",
+		Passive:     relentless,
+		PassiveText: "Gains +2 attack every turn of a battle (max +10)",
+		Stats: model.Player{
+			Health:      140,
+			MaxHealth:   140,
+			AttackMin:   10,
+			AttackMax:   14,
+			Defense:     2,
+			CritChance:  10,
+			BlockChance: 10,
+			Speed:       10,
+		},
+	},
+	{
+		Name:        "Thraex",
+		Description: "Aggressive duelist wielding a curved sica",
+		Passive:     curvedBlade,
+		UnlockPrice: 300,
+		PassiveText: "Critical hits ignore the opponent's defense",
+		Stats: model.Player{
+			Health:      125,
+			MaxHealth:   125,
+			AttackMin:   10,
+			AttackMax:   16,
+			Defense:     1,
+			CritChance:  15,
+			BlockChance: 8,
+			Speed:       11,
+			CritDamage:  20,
+		},
+	},
+}
+
+func init() {
+	RegisterHook(scutumWall, HookFuncs{Block: func(ctx *HitContext) {
+		// A blocked hit still deals the minimum damage of every attack
+		if !ctx.IsAttacker() {
+			ctx.Damage = max(1, ctx.Damage/2)
+		}
+	}})
+
+	RegisterHook(netThrow, HookFuncs{TurnStart: func(ctx *TurnContext) {
+		if ctx.OwnerTurn != 1 {
+			return
+		}
+		ctx.Opponent.AddBuff(model.Buff{
+			Name:   "Netted",
+			Source: netThrow,
+			Scope:  model.BuffTurns,
+			Turns:  netThrowTurns,
+			Mods:   model.Modifiers{BlockChance: -15, Defense: -3},
+		})
+		ctx.Log(buffEntry(ctx.Owner, fmt.Sprintf("%s entangles %s in a net!", ctx.Owner.Name, ctx.Opponent.Name)))
+	}})
+
+	RegisterHook(relentless, HookFuncs{TurnStart: func(ctx *TurnContext) {
+		if ctx.OwnerTurn == 1 {
+			return
+		}
+		ctx.Owner.AddBuff(model.Buff{
+			Name:      relentless,
+			Source:    relentless,
+			MaxStacks: 5,
+			Scope:     model.BuffBattle,
+			Mods:      model.Modifiers{AttackMin: 2, AttackMax: 2},
+		})
+	}})
+
+	RegisterHook(curvedBlade, HookFuncs{Crit: func(ctx *HitContext) {
+		// Only the defense that actually stopped damage is given back, so a crit
+		// never deals more than it would against no armor at all
+		if ctx.IsAttacker() {
+			ctx.Damage += ctx.Defended
+		}
+	}})
+}
+
+// HeroClasses returns all classes the hero can pick
+func HeroClasses() []HeroClass {
+	return heroClasses
+}
+
+// FindHeroClass returns the class with the given name
+func FindHeroClass(name string) (HeroClass, bool) {
+	for _, class := range heroClasses {
+		if class.Name == name {
+			return class, true
+		}
+	}
+	return HeroClass{}, false
+}
+
+// heroClass returns the class with the given name, unknown names get the default class
+func heroClass(name string) HeroClass {
+	if class, ok := FindHeroClass(name); ok {
+		return class
+	}
+	return heroClasses[0]
+}
+
+// startingHero returns a fresh hero of the class
+func (c HeroClass) startingHero(playerName string) model.Player {
+	hero := c.Stats
+	hero.Name = playerName
+	hero.Class = c.Name
+	hero.IsHero = true
+	hero.Hooks = []string{c.Passive}
+	hero.Inventory = map[model.Consumable]int{model.HealingDraught: 1}
+	return hero
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+
+	model "gladiator-sim/models"
+)
+
+const (
+	draughtHeal     = 30 // Percent of max health a Healing Draught restores
+	whetstoneAttack = 4  // Attack a Whetstone adds to both ends of the damage range
+	whetstoneTurns  = 3
+	smokeBlock      = 50 // Block chance a Smoke Bomb adds until the end of the user's next turn
+	findChance      = 40 // Percent chance to find a consumable after a victory
+)
+
+// consumableEffect applies a consumable used by user and returns the battle log entry describing it
+type consumableEffect func(user, opponent *model.Player) model.LogEntry
+
+var consumableEffects = map[model.Consumable]consumableEffect{
+	model.HealingDraught: func(user, opponent *model.Player) model.LogEntry {
+		healed := min(user.MaxHealth*draughtHeal/100, user.MaxHealth-user.Health)
+		user.Health += healed
+		return model.LogEntry{
+			Kind:   model.LogRegen,
+			Actor:  user.Name,
+			Amount: healed,
+			Text:   fmt.Sprintf("%s drinks a Healing Draught and recovers %d HP!", user.Name, healed),
+		}
+	},
+	model.Whetstone: func(user, opponent *model.Player) model.LogEntry {
+		user.AddBuff(model.Buff{
+			Name:   string(model.Whetstone),
+			Source: string(model.Whetstone),
+			Scope:  model.BuffTurns,
+			Turns:  whetstoneTurns,
+			Mods:   model.Modifiers{AttackMin: whetstoneAttack, AttackMax: whetstoneAttack},
+		})
+		return buffEntry(user, fmt.Sprintf("%s sharpens the blade on a Whetstone!", user.Name))
+	},
+	model.SmokeBomb: func(user, opponent *model.Player) model.LogEntry {
+		// Buffs tick at the end of the owner's turns, so the smoke covers every attack the
+		// enemy makes until the user's next turn is over, more of them against faster enemies
+		user.AddBuff(model.Buff{
+			Name:   string(model.SmokeBomb),
+			Source: string(model.SmokeBomb),
+			Scope:  model.BuffTurns,
+			Turns:  2,
+			Mods:   model.Modifiers{BlockChance: smokeBlock},
+		})
+		return buffEntry(user, fmt.Sprintf("%s throws a Smoke Bomb, %s can barely see!", user.Name, opponent.Name))
+	},
+}
+
+// UseConsumable queues a consumable for the hero's next turn. It is safe to call
+// while a battle runs in the background, the battle takes it out of the inventory.
+func (h *GameHandler) UseConsumable(c model.Consumable) {
+	select {
+	case h.actionQueue() <- c:
+	default:
+		// The queue is full, a player mashing keys loses the extra presses
+	}
+}
+
+// actionQueue returns the consumables waiting for the hero's next turn
+func (h *GameHandler) actionQueue() chan model.Consumable {
+	h.actionsOnce.Do(func() {
+		h.actions = make(chan model.Consumable, len(model.Consumables))
+	})
+	return h.actions
+}
+
+// clearActions drops consumables queued for a battle that is already over
+func (h *GameHandler) clearActions() {
+	for {
+		select {
+		case <-h.actionQueue():
+		default:
+			return
+		}
+	}
+}
+
+// useQueued applies the consumables the hero queued since its last turn
+func (b *Battle) useQueued() {
+	for {
+		select {
+		case c := <-b.handler.actionQueue():
+			effect, ok := consumableEffects[c]
+			if !ok || !b.Hero.TakeConsumable(c) {
+				continue
+			}
+			b.log(effect(b.Hero, b.Enemy))
+		default:
+			return
+		}
+	}
+}
+
+// findConsumable gives the hero a random consumable now and then after a victory
+func findConsumable(rng *rand.Rand, hero *model.Player) (model.Consumable, bool) {
+	if rng.Intn(100) >= findChance {
+		return "", false
+	}
+	found := model.Consumables[rng.Intn(len(model.Consumables))]
+	hero.AddConsumable(found, 1)
+	return found, true
+}
@@ -0,0 +1,151 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	model "gladiator-sim/models"
+)
+
+// Crowd favor tuning
+const (
+	favorPerDemand = 25 // Favor gained by meeting a demand
+	favorPerFail   = 10 // Favor lost by failing a demand
+	favorHealing   = 5  // Points of favor per percent of max health the crowd heals after a met demand
+	quickWinTurns  = 5  // A swift victory takes fewer of the hero's turns than this
+	critsDemanded  = 3
+)
+
+// audienceDemand is something the crowd may want to see in a battle
+type audienceDemand struct {
+	Name string
+	Text string
+	Goal int // Progress needed, 0 means the demand is met as long as it did not fail
+	// track updates the demand after every attack of the battle
+	track func(d *model.Demand, b *Battle, result model.BattleResult)
+}
+
+// audienceDemands are all demands the crowd can make
+var audienceDemands = []audienceDemand{
+	{
+		Name: "Killing Blow",
+		Text: "Win with a critical hit",
+		Goal: 1,
+		track: func(d *model.Demand, b *Battle, result model.BattleResult) {
+			if result.IsGameOver && result.Attacker == b.Hero && result.IsCritical {
+				d.Progress = 1
+			}
+		},
+	},
+	{
+		Name: "Bloodshed",
+		Text: fmt.Sprintf("Land %d critical hits", critsDemanded),
+		Goal: critsDemanded,
+		track: func(d *model.Demand, b *Battle, result model.BattleResult) {
+			if result.Attacker == b.Hero && result.IsCritical {
+				d.Progress++
+			}
+		},
+	},
+	{
+		Name: "Swift Victory",
+		Text: fmt.Sprintf("Win in under %d turns", quickWinTurns),
+		track: func(d *model.Demand, b *Battle, result model.BattleResult) {
+			if result.Attacker == b.Hero {
+				d.Progress = b.Hero.Counter(turnsCounter)
+				d.Failed = d.Failed || d.Progress >= quickWinTurns
+			}
+		},
+	},
+	{
+		Name: "No Shield",
+		Text: "Never block an attack",
+		track: func(d *model.Demand, b *Battle, result model.BattleResult) {
+			d.Failed = d.Failed || (result.Defender == b.Hero && result.IsBlocked)
+		},
+	},
+	{
+		Name: "Untouchable",
+		Text: "Never drop below half health",
+		track: func(d *model.Demand, b *Battle, result model.BattleResult) {
+			// The deepest wound counts, even when the hero healed before the attack was over
+			d.Failed = d.Failed || b.Hero.Counter(woundCounter)*2 > b.Hero.MaxHealth
+		},
+	},
+}
+
+// rollDemand picks what the audience wants to see in the next battle
+func rollDemand(rng *rand.Rand) *model.Demand {
+	demand := audienceDemands[rng.Intn(len(audienceDemands))]
+	return &model.Demand{Name: demand.Name, Text: demand.Text, Goal: demand.Goal}
+}
+
+// isMet reports whether the hero did what the crowd asked for
+func isMet(d *model.Demand) bool {
+	return !d.Failed && d.Progress >= d.Goal
+}
+
+// trackDemand checks the outcome of an attack or damage over time against the demand of the battle
+func (b *Battle) trackDemand(result model.BattleResult) {
+	d := b.State.Demand
+	if d == nil {
+		return
+	}
+	for _, demand := range audienceDemands {
+		if demand.Name == d.Name {
+			demand.track(d, b, result)
+		}
+	}
+}
+
+// judgeDemand settles the demand after the hero won. A met demand raises the favor
+// and the crowd heals the hero the more it loves them, a failed one costs favor.
+func (b *Battle) judgeDemand() {
+	state, hero := b.State, b.Hero
+	if state.Demand == nil {
+		return
+	}
+
+	if !isMet(state.Demand) {
+		state.Favor = max(0, state.Favor-favorPerFail)
+		b.log(model.LogEntry{
+			Kind:  model.LogNarration,
+			Actor: hero.Name,
+			Text:  fmt.Sprintf("📣 The crowd jeers, it wanted you to %s. Favor drops to %d.", lowerFirst(state.Demand.Text), state.Favor),
+		})
+		return
+	}
+
+	state.Favor = min(model.MaxFavor, state.Favor+favorPerDemand)
+	healed := min(hero.MaxHealth*state.Favor/favorHealing/100, hero.MaxHealth-hero.Health)
+	heal(hero, healed)
+	b.log(model.LogEntry{
+		Kind:   model.LogVictory,
+		Actor:  hero.Name,
+		Amount: healed,
+		Text:   fmt.Sprintf("📣 The crowd roars! Demand met, favor rises to %d and roses heal you for %d.", state.Favor, healed),
+	})
+}
+
+// spendFavor turns a full favor meter into a rarer upgrade choice and reports whether it did
+func (b *Battle) spendFavor() bool {
+	if b.State.Favor < model.MaxFavor {
+		return false
+	}
+	b.State.Favor = 0
+	b.log(model.LogEntry{
+		Kind:  model.LogUpgrade,
+		Actor: b.Hero.Name,
+		Text:  "📣 The crowd adores you! Its favor earns you a rarer choice.",
+	})
+	return true
+}
+
+// lowerFirst returns text with its first letter in lower case
+func lowerFirst(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToLower(text[:1]) + text[1:]
+}
@@ -0,0 +1,22 @@
+package game
+
+import model "gladiator-sim/models"
+
+// maxResistance caps resistances so every type of damage still hurts a little
+const maxResistance = 90
+
+// resist reduces damage of the given type by the defender's resistance to it,
+// a negative resistance is a weakness that increases the damage instead
+func resist(damage int, defender *model.Player, damageType model.DamageType) int {
+	resistance := min(defender.Resistances.Get(damageType), maxResistance)
+	return damage * (100 - resistance) / 100
+}
+
+// damageTypeText names the damage types of a hit for the battle log, e.g. "slashing and fire"
+func damageTypeText(types []model.DamageType) string {
+	names := make([]string, len(types))
+	for i, damageType := range types {
+		names[i] = string(damageType)
+	}
+	return joinWithAnd(names)
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	model "gladiator-sim/models"
+)
+
+const (
+	endlessAffixEvery = 3 // Levels of depth that add another affix to endless enemies
+	endlessScaling    = 5 // Percent more health and attack per level of depth, on top of the boss formula
+)
+
+// createEndlessEnemy generates a challenger past the end of the roster: a random
+// archetype scaled like a boss, with more affixes the deeper the hero goes
+func (h *GameHandler) createEndlessEnemy(rng *rand.Rand, level int) *model.Player {
+	roster := h.roster()
+	archetypes := append(slices.Clone(roster.Enemies), roster.Bosses...)
+
+	depth := h.EndlessDepth(level)
+	enemy := newBoss(archetypes[rng.Intn(len(archetypes))], level)
+
+	// The boss formula grows linearly, heroes deep in endless mode outgrow that
+	scale := 100 + depth*endlessScaling
+	enemy.MaxHealth = enemy.MaxHealth * scale / 100
+	enemy.Health = enemy.MaxHealth
+	enemy.AttackMin = enemy.AttackMin * scale / 100
+	enemy.AttackMax = enemy.AttackMax * scale / 100
+
+	applyAffixes(rng, enemy, 1+depth/endlessAffixEvery)
+	return enemy
+}
+
+// EndlessDepth returns how far past the final boss the enemy at level is, 0 within the roster
+func (h *GameHandler) EndlessDepth(level int) int {
+	return max(0, level-h.roster().Levels())
+}
+
+// ContinueEndless carries a run that beat the final boss on into endless mode and
+// reports whether it could. The hero picks an upgrade before the first endless battle.
+func (h *GameHandler) ContinueEndless(hero, enemy *model.Player, state *model.GameState) bool {
+	if !state.GameOver || state.Endless || hero.Health <= 0 {
+		return false
+	}
+
+	state.GameOver = false
+	state.Endless = true
+	state.UpgradeMode = true
+	state.Upgrades = h.CreateUpgrades(state.Rng, hero)
+	state.SelectedUpgrade = 0
+	state.Narrate("The crowd demands more! Choose an upgrade and descend into the endless arena.")
+
+	h.autoSave(hero, enemy, state)
+	return true
+}
+
+// recordDepth notes how deep an endless run got and keeps it as the high score
+func (h *GameHandler) recordDepth(state *model.GameState, hero *model.Player, depth int) model.LogEntry {
+	text := fmt.Sprintf("Depth reached: %d", depth)
+	if h.Profile != nil {
+		if depth > h.Profile.BestDepth {
+			h.Profile.BestDepth = depth
+			h.saveProfile(state)
+			text += " - a new record!"
+		} else {
+			text += fmt.Sprintf(" (best: %d)", h.Profile.BestDepth)
+		}
+	}
+	return model.LogEntry{Kind: model.LogVictory, Actor: hero.Name, Amount: depth, Text: text}
+}
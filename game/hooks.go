@@ -0,0 +1,286 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	model "gladiator-sim/models"
+)
+
+// Hook reacts to well-defined points of a battle. Hooks are registered by name
+// and attached to players through model.Player.Hooks, so any state they need
+// (counters, buffs) has to live on the player and not in the hook itself.
+type Hook interface {
+	// OnTurnStart runs for the acting player's hooks before it attacks
+	OnTurnStart(ctx *TurnContext)
+	// OnCrit runs for both combatants' hooks when an attack is a critical hit
+	OnCrit(ctx *HitContext)
+	// OnBlock runs for both combatants' hooks when the defender blocked
+	OnBlock(ctx *HitContext)
+	// OnHit runs for both combatants' hooks when an attack lands, after OnCrit and OnBlock
+	// but before the damage is applied
+	OnHit(ctx *HitContext)
+	// OnDeath runs for the hooks of a combatant whose health dropped to zero, the
+	// dying one is ctx.Owner and hooks set ctx.Prevented to keep it alive
+	OnDeath(ctx *HitContext)
+	// OnKill runs for the hooks of the combatant that killed the other, which is ctx.Owner
+	OnKill(ctx *HitContext)
+}
+
+// HookFuncs implements Hook with optional functions, unset ones do nothing
+type HookFuncs struct {
+	TurnStart func(ctx *TurnContext)
+	Crit      func(ctx *HitContext)
+	Block     func(ctx *HitContext)
+	Hit       func(ctx *HitContext)
+	Death     func(ctx *HitContext)
+	Kill      func(ctx *HitContext)
+}
+
+func (f HookFuncs) OnTurnStart(ctx *TurnContext) {
+	if f.TurnStart != nil {
+		f.TurnStart(ctx)
+	}
+}
+
+func (f HookFuncs) OnCrit(ctx *HitContext) {
+	if f.Crit != nil {
+		f.Crit(ctx)
+	}
+}
+
+func (f HookFuncs) OnBlock(ctx *HitContext) {
+	if f.Block != nil {
+		f.Block(ctx)
+	}
+}
+
+func (f HookFuncs) OnHit(ctx *HitContext) {
+	if f.Hit != nil {
+		f.Hit(ctx)
+	}
+}
+
+func (f HookFuncs) OnDeath(ctx *HitContext) {
+	if f.Death != nil {
+		f.Death(ctx)
+	}
+}
+
+func (f HookFuncs) OnKill(ctx *HitContext) {
+	if f.Kill != nil {
+		f.Kill(ctx)
+	}
+}
+
+// TurnContext is passed to OnTurnStart hooks
+type TurnContext struct {
+	Rng      *rand.Rand
+	Owner    *model.Player // Player whose hook is running
+	Opponent *model.Player
+	Turn     int // Turn about to be played, starting at 1
+	// Turns the owner has started this battle, including this one
+	OwnerTurn int
+	Attacks   int // Attacks the owner makes this turn, 0 skips the turn
+	Events    []model.LogEntry
+}
+
+// Log adds an entry to the battle log after the turn started
+func (ctx *TurnContext) Log(entry model.LogEntry) {
+	ctx.Events = append(ctx.Events, entry)
+}
+
+// HitContext is passed to the hooks of a single attack
+type HitContext struct {
+	Rng        *rand.Rand
+	Owner      *model.Player // Player whose hook is running
+	Attacker   *model.Player
+	Defender   *model.Player
+	Damage     int // Damage about to be dealt, hooks may change it until it is applied
+	IsCritical bool
+	IsBlocked  bool
+	Defended   int                  // Weapon damage the defender's defense stopped
+	Prevented  bool                 // Set by OnDeath hooks to keep the dying combatant alive
+	Arena      model.ArenaCondition // Condition the battle is fought under
+	Events     []model.LogEntry
+
+	applied   bool
+	heals     []pendingHeal
+	reflected int
+}
+
+// pendingHeal is a heal queued before the damage was applied
+type pendingHeal struct {
+	target *model.Player
+	amount int
+}
+
+// IsAttacker reports whether the running hook belongs to the attacker
+func (ctx *HitContext) IsAttacker() bool {
+	return ctx.Owner == ctx.Attacker
+}
+
+// Log adds an entry to the battle log after the attack
+func (ctx *HitContext) Log(entry model.LogEntry) {
+	ctx.Events = append(ctx.Events, entry)
+}
+
+// Heal restores health to a combatant. Heals requested before the damage is
+// applied are held back until afterwards, so they cannot be wasted at full health.
+func (ctx *HitContext) Heal(target *model.Player, amount int) {
+	if amount <= 0 {
+		return
+	}
+	if !ctx.applied {
+		ctx.heals = append(ctx.heals, pendingHeal{target: target, amount: amount})
+		return
+	}
+	heal(target, amount)
+}
+
+// Reflect deals damage back to the attacker together with the attack's own damage
+func (ctx *HitContext) Reflect(amount int) {
+	if amount <= 0 {
+		return
+	}
+	if !ctx.applied {
+		ctx.reflected += amount
+		return
+	}
+	ctx.Attacker.Health -= amount
+}
+
+// applyDamage deals the final and reflected damage, then the held back heals
+func (ctx *HitContext) applyDamage() {
+	if ctx.Damage < 0 {
+		ctx.Damage = 0
+	}
+	ctx.Defender.Health -= ctx.Damage
+	ctx.Attacker.Health -= ctx.reflected
+	ctx.applied = true
+
+	// Recorded before anyone heals, so a wound healed in the same attack still counts
+	for _, p := range []*model.Player{ctx.Defender, ctx.Attacker} {
+		p.RaiseCounter(woundCounter, p.MaxHealth-p.Health)
+	}
+
+	for _, h := range ctx.heals {
+		heal(h.target, h.amount)
+	}
+	ctx.heals = nil
+}
+
+// heal restores health without exceeding the maximum
+func heal(p *model.Player, amount int) {
+	p.Health += amount
+	if p.Health > p.MaxHealth {
+		p.Health = p.MaxHealth
+	}
+}
+
+// hookRegistry holds all named hooks, it is only written during package initialization
+var hookRegistry = map[string]Hook{}
+
+// RegisterHook makes a hook available under name for model.Player.Hooks
+func RegisterHook(name string, hook Hook) {
+	if _, exists := hookRegistry[name]; exists {
+		panic(fmt.Sprintf("hook %q registered twice", name))
+	}
+	hookRegistry[name] = hook
+}
+
+// hooksFor returns the hooks of a player and its worn items, the core mechanics every combatant has come last
+// so they see the damage after all other hooks changed it
+func hooksFor(p *model.Player) []Hook {
+	names := slices.Clone(p.Hooks)
+	for _, item := range p.EquippedItems() {
+		names = append(names, item.Hooks...)
+	}
+
+	hooks := make([]Hook, 0, len(names)+len(coreHooks))
+	for _, name := range names {
+		if hook, ok := hookRegistry[name]; ok {
+			hooks = append(hooks, hook)
+		}
+	}
+	return append(hooks, coreHooks...)
+}
+
+// runHitHooks calls event on the hooks of each given player with it as owner
+func runHitHooks(ctx *HitContext, event func(Hook, *HitContext), owners ...*model.Player) {
+	for _, owner := range owners {
+		ctx.Owner = owner
+		for _, hook := range hooksFor(owner) {
+			event(hook, ctx)
+		}
+	}
+	ctx.Owner = nil
+}
+
+// coreHooks implement the stat driven mechanics shared by every combatant
+var coreHooks = []Hook{
+	// Life steal heals the attacker for a share of the damage dealt, the arena may change the share
+	HookFuncs{Hit: func(ctx *HitContext) {
+		if !ctx.IsAttacker() {
+			return
+		}
+		lifeSteal := ctx.Attacker.Effective().LifeSteal
+		if lifeSteal > 0 {
+			lifeSteal = percentChange(lifeSteal, ctx.Arena.LifeStealPercent)
+			ctx.Heal(ctx.Attacker, healing(ctx.Attacker, int(float64(ctx.Damage)*float64(lifeSteal)/100.0)))
+		}
+	}},
+	// Regeneration heals the defender for a share of its max health whenever it is hit, the arena
+	// may change the share and grievous wounds reduce this and every other healing from stats
+	HookFuncs{Hit: func(ctx *HitContext) {
+		if ctx.IsAttacker() {
+			return
+		}
+		regeneration := percentChange(ctx.Defender.Effective().Regeneration, ctx.Arena.RegenerationPercent)
+		if regeneration <= 0 {
+			return
+		}
+		healAmount := healing(ctx.Defender, int(float64(ctx.Defender.MaxHealth)*float64(regeneration)/100.0))
+		if healAmount <= 0 {
+			return
+		}
+		ctx.Heal(ctx.Defender, healAmount)
+		ctx.Log(model.LogEntry{
+			Kind:   model.LogRegen,
+			Actor:  ctx.Defender.Name,
+			Amount: healAmount,
+			Text:   fmt.Sprintf("%s regenerates %d health.", ctx.Defender.Name, healAmount),
+		})
+	}},
+	// Revive buffs bring a fallen combatant back instead of letting it die
+	HookFuncs{Death: func(ctx *HitContext) {
+		if ctx.Prevented || !revive(ctx.Owner) {
+			return
+		}
+		ctx.Prevented = true
+		ctx.Log(model.LogEntry{
+			Kind:   model.LogBuff,
+			Actor:  ctx.Owner.Name,
+			Amount: ctx.Owner.Health,
+			Text:   fmt.Sprintf("✨ %s refuses to die and rises again with %d health!", ctx.Owner.Name, ctx.Owner.Health),
+		})
+	}},
+	// Life on kill heals the killer after finishing an opponent
+	HookFuncs{Kill: func(ctx *HitContext) {
+		ctx.Heal(ctx.Owner, healing(ctx.Owner, ctx.Owner.Effective().LifeOnKill))
+	}},
+}
+
+// revive consumes a revive buff of a fallen player and restores its health
+func revive(p *model.Player) bool {
+	for _, buff := range p.Buffs {
+		if buff.Mods.Revive <= 0 {
+			continue
+		}
+		p.Health = max(1, p.MaxHealth*buff.Mods.Revive/100)
+		p.ConsumeBuff(buff.Name)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package game
+
+import model "gladiator-sim/models"
+
+const (
+	baseSpeed           = 10  // Speed of enemies whose roster entry does not set one
+	initiativeThreshold = 100 // Initiative a combatant needs to take a turn
+	heavyStrikeDelay    = 50  // Initiative a Heavy Strike costs on top of the turn
+)
+
+// speed returns how much initiative a combatant gains per tick, with all modifiers applied
+func speed(p *model.Player) int {
+	return max(1, p.Effective().Speed)
+}
+
+// ticksUntilReady returns how many ticks a combatant needs until it can act
+func ticksUntilReady(initiative, speed int) int {
+	if initiative >= initiativeThreshold {
+		return 0
+	}
+	return (initiativeThreshold - initiative + speed - 1) / speed
+}
+
+// nextActor returns who acts next on the initiative timeline and how many ticks
+// pass until then. The hero wins ties, so equally fast combatants alternate.
+func (b *Battle) nextActor() (*model.Player, int) {
+	heroTicks := ticksUntilReady(b.heroInitiative, speed(b.Hero))
+	enemyTicks := ticksUntilReady(b.enemyInitiative, speed(b.Enemy))
+	if heroTicks <= enemyTicks {
+		return b.Hero, heroTicks
+	}
+	return b.Enemy, enemyTicks
+}
+
+// advanceTimeline moves the timeline to the next turn and returns who takes it
+func (b *Battle) advanceTimeline() *model.Player {
+	actor, ticks := b.nextActor()
+	b.heroInitiative += ticks * speed(b.Hero)
+	b.enemyInitiative += ticks * speed(b.Enemy)
+
+	if actor == b.Hero {
+		b.heroInitiative -= initiativeThreshold
+	} else {
+		b.enemyInitiative -= initiativeThreshold
+	}
+	return actor
+}
+
+// delay pushes a combatant back on the timeline, e.g. after a slow action
+func (b *Battle) delay(p *model.Player, initiative int) {
+	if p == b.Hero {
+		b.heroInitiative -= initiative
+	} else {
+		b.enemyInitiative -= initiative
+	}
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+
+	model "gladiator-sim/models"
+)
+
+// defaultItemsJSON is the item catalog the game ships with
+//
+//go:embed content/items.json
+var defaultItemsJSON []byte
+
+// ItemCatalog is the set of items enemies can drop
+type ItemCatalog struct {
+	Items []model.Item `json:"items"`
+}
+
+// Find returns the item with the given name
+func (c *ItemCatalog) Find(name string) (model.Item, bool) {
+	for _, item := range c.Items {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return model.Item{}, false
+}
+
+var (
+	defaultItems     *ItemCatalog
+	defaultItemsOnce sync.Once
+)
+
+// DefaultItemCatalog returns the embedded item catalog
+func DefaultItemCatalog() *ItemCatalog {
+	// Parsed lazily, validation needs the hooks registered during package initialization
+	defaultItemsOnce.Do(func() {
+		catalog, err := ParseItemCatalog(defaultItemsJSON)
+		if err != nil {
+			panic(fmt.Sprintf("embedded item catalog is invalid: %v", err))
+		}
+		defaultItems = catalog
+	})
+	return defaultItems
+}
+
+// ParseItemCatalog decodes and validates an item catalog from JSON
+func ParseItemCatalog(data []byte) (*ItemCatalog, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Typos in field names would silently fall back to zero otherwise
+	decoder.DisallowUnknownFields()
+
+	var catalog ItemCatalog
+	if err := decoder.Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("decoding items: %w", err)
+	}
+	if err := catalog.validate(); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// validate checks that every item has a known slot and only refers to hooks that exist
+func (c *ItemCatalog) validate() error {
+	names := map[string]bool{}
+	for _, item := range c.Items {
+		if item.Name == "" {
+			return errors.New("item without a name")
+		}
+		if names[item.Name] {
+			return fmt.Errorf("item %q is defined twice", item.Name)
+		}
+		names[item.Name] = true
+
+		if !slices.Contains(model.Slots, item.Slot) {
+			return fmt.Errorf("item %q: unknown slot %q", item.Name, item.Slot)
+		}
+		// Worn items are never used up, a revive would trigger every time
+		if item.Mods.Revive != 0 {
+			return fmt.Errorf("item %q: items cannot revive", item.Name)
+		}
+		for _, hook := range item.Hooks {
+			if _, ok := hookRegistry[hook]; !ok {
+				return fmt.Errorf("item %q: unknown hook %q", item.Name, hook)
+			}
+		}
+	}
+	return nil
+}
+
+// LootDrop is an item an enemy may drop when defeated
+type LootDrop struct {
+	Item   string `json:"item"`
+	Chance int    `json:"chance"` // Percent chance the item drops
+}
+
+// rollLoot returns the items the enemy at level drops
+func (h *GameHandler) rollLoot(rng *rand.Rand, level int) []model.Item {
+	items := []model.Item{}
+	for _, drop := range h.roster().At(level).Loot {
+		if rng.Intn(100) >= drop.Chance {
+			continue
+		}
+		if item, ok := DefaultItemCatalog().Find(drop.Item); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// offerLoot creates the choice to equip an item, naming the item it would replace
+func offerLoot(hero *model.Player, item model.Item) model.Upgrade {
+	description := fmt.Sprintf("%s: %s", item.Slot, item.Description)
+	if worn, ok := hero.Equipment[item.Slot]; ok {
+		description += " (replaces " + worn.Name + ")"
+	}
+
+	return model.Upgrade{
+		Name:        "Equip " + item.Name,
+		Description: description,
+		Item:        item.Name,
+		Effect: func(p *model.Player) {
+			p.Equip(item)
+		},
+	}
+}
+
+// lootOffers rolls the loot of the enemy at level and turns it into upgrade choices
+func (h *GameHandler) lootOffers(rng *rand.Rand, hero *model.Player, level int) []model.Upgrade {
+	offers := []model.Upgrade{}
+	for _, item := range h.rollLoot(rng, level) {
+		offers = append(offers, offerLoot(hero, item))
+	}
+	return offers
+}
@@ -0,0 +1,222 @@
+package game
+
+import (
+	"fmt"
+
+	model "gladiator-sim/models"
+)
+
+// Patron is a god the hero can pledge a run to. It grants a passive bonus from the
+// start and a blessing the player invokes once fighting has charged it.
+type Patron struct {
+	Name         string
+	Description  string
+	PassiveText  string // What the passive bonus does
+	Blessing     string // Name of the active blessing
+	BlessingText string // What invoking the blessing does
+	Charge       int    // Hero turns in a battle needed to charge the blessing
+	passive      func(p *model.Player)
+	// bless carries out the blessing for the hero of the battle and returns the log entry describing it
+	bless func(b *Battle) model.LogEntry
+}
+
+// Names of the blessings that need a hook or a buff
+const (
+	wrathOfMars    = "Wrath of Mars"
+	fortunesFavor  = "Fortune's Favor"
+	retribution    = "Retribution"
+	fortunesTurns  = 3
+	blessingSymbol = "✨"
+)
+
+// patrons are all gods the hero can pick
+var patrons = []Patron{
+	{
+		Name:         "Mars",
+		Description:  "God of war, who loves a brutal fight",
+		PassiveText:  "+2 Min and Max ATK",
+		Blessing:     wrathOfMars,
+		BlessingText: "Your next hit deals double damage",
+		Charge:       3,
+		passive: func(p *model.Player) {
+			p.AttackMin += 2
+			p.AttackMax += 2
+		},
+		bless: func(b *Battle) model.LogEntry {
+			b.Hero.AddHook(wrathOfMars)
+			b.Hero.AddBuff(model.Buff{
+				Name:      wrathOfMars,
+				Source:    "Mars",
+				MaxStacks: 1,
+				Scope:     model.BuffBattle,
+			})
+			return buffEntry(b.Hero, fmt.Sprintf("%s Mars fills %s with wrath, the next hit will land twice as hard!", blessingSymbol, b.Hero.Name))
+		},
+	},
+	{
+		Name:         "Fortuna",
+		Description:  "Goddess of luck, who favors the bold",
+		PassiveText:  "+5% crit chance",
+		Blessing:     fortunesFavor,
+		BlessingText: fmt.Sprintf("Failed crit rolls are rolled again for %d turns", fortunesTurns),
+		Charge:       3,
+		passive: func(p *model.Player) {
+			p.CritChance += 5
+		},
+		bless: func(b *Battle) model.LogEntry {
+			// Buffs tick at the end of the owner's turns, so this turn counts as the first
+			b.Hero.AddBuff(model.Buff{
+				Name:   fortunesFavor,
+				Source: "Fortuna",
+				Scope:  model.BuffTurns,
+				Turns:  fortunesTurns,
+				Mods:   model.Modifiers{CritRerolls: 1},
+			})
+			return buffEntry(b.Hero, fmt.Sprintf("%s Fortuna smiles on %s, missed crits get a second roll!", blessingSymbol, b.Hero.Name))
+		},
+	},
+	{
+		Name:         "Nemesis",
+		Description:  "Goddess of retribution, who repays every blow",
+		PassiveText:  "+2 DEF",
+		Blessing:     retribution,
+		BlessingText: "The next hit you take is dealt back to the attacker in full",
+		Charge:       3,
+		passive: func(p *model.Player) {
+			p.Defense += 2
+		},
+		bless: func(b *Battle) model.LogEntry {
+			b.Hero.AddHook(retribution)
+			b.Hero.AddBuff(model.Buff{
+				Name:      retribution,
+				Source:    "Nemesis",
+				MaxStacks: 1,
+				Scope:     model.BuffBattle,
+			})
+			return buffEntry(b.Hero, fmt.Sprintf("%s Nemesis watches over %s, the next blow will be repaid!", blessingSymbol, b.Hero.Name))
+		},
+	},
+	{
+		Name:         "Mercury",
+		Description:  "Swift messenger of the gods",
+		PassiveText:  "+2 SPD",
+		Blessing:     "Quicksilver",
+		BlessingText: "You act again right after this turn",
+		Charge:       4,
+		passive: func(p *model.Player) {
+			p.Speed += 2
+		},
+		bless: func(b *Battle) model.LogEntry {
+			// A full turn of initiative puts the hero first in line once this turn is over
+			b.heroInitiative += initiativeThreshold
+			return buffEntry(b.Hero, fmt.Sprintf("%s Mercury lends %s winged feet, another turn follows!", blessingSymbol, b.Hero.Name))
+		},
+	},
+}
+
+func init() {
+	// The next hit after invoking Mars deals double damage
+	RegisterHook(wrathOfMars, HookFuncs{Hit: func(ctx *HitContext) {
+		if !ctx.IsAttacker() || ctx.Owner.GetBuff(wrathOfMars) == nil {
+			return
+		}
+		ctx.Damage *= 2
+		ctx.Owner.RemoveBuff(wrathOfMars)
+		ctx.Log(buffEntry(ctx.Owner, "The wrath of Mars doubles the blow!"))
+	}})
+
+	// The next hit taken after invoking Nemesis is dealt back to the attacker
+	RegisterHook(retribution, HookFuncs{Hit: func(ctx *HitContext) {
+		if ctx.IsAttacker() || ctx.Damage <= 0 || ctx.Owner.GetBuff(retribution) == nil {
+			return
+		}
+		ctx.Reflect(ctx.Damage)
+		ctx.Owner.RemoveBuff(retribution)
+		ctx.Log(model.LogEntry{
+			Kind:   model.LogBuff,
+			Actor:  ctx.Owner.Name,
+			Target: ctx.Attacker.Name,
+			Amount: ctx.Damage,
+			Text:   fmt.Sprintf("Nemesis repays %s for %d damage.", ctx.Attacker.Name, ctx.Damage),
+		})
+	}})
+}
+
+// Patrons returns all gods the hero can pledge a run to
+func Patrons() []Patron {
+	return patrons
+}
+
+// FindPatron returns the patron with the given name
+func FindPatron(name string) (Patron, bool) {
+	for _, patron := range patrons {
+		if patron.Name == name {
+			return patron, true
+		}
+	}
+	return Patron{}, false
+}
+
+// pledge puts the hero under the patron's protection
+func (p Patron) pledge(hero *model.Player) {
+	p.passive(hero)
+	hero.Patron = p.Name
+	hero.Blessing = &model.Blessing{Name: p.Blessing, Needed: p.Charge}
+}
+
+// InvokeBlessing asks the hero's patron for its blessing. It is safe to call while a
+// battle runs in the background, the battle answers on the hero's next turn.
+func (h *GameHandler) InvokeBlessing() {
+	select {
+	case h.blessingQueue() <- struct{}{}:
+	default:
+		// The blessing was already asked for this turn
+	}
+}
+
+// blessingQueue returns the channel invoked blessings wait in for the hero's turn
+func (h *GameHandler) blessingQueue() chan struct{} {
+	h.blessingsOnce.Do(func() {
+		h.blessings = make(chan struct{}, 1)
+	})
+	return h.blessings
+}
+
+// clearBlessing drops a blessing invoked for a battle that is already over
+func (h *GameHandler) clearBlessing() {
+	select {
+	case <-h.blessingQueue():
+	default:
+	}
+}
+
+// useBlessing carries out a blessing the player invoked since the hero's last turn
+func (b *Battle) useBlessing() {
+	select {
+	case <-b.handler.blessingQueue():
+		b.bless()
+	default:
+	}
+}
+
+// bless carries out the hero's blessing when it is charged, or tells how far it is
+func (b *Battle) bless() {
+	blessing := b.Hero.Blessing
+	patron, ok := FindPatron(b.Hero.Patron)
+	if blessing == nil || !ok {
+		return
+	}
+	if !blessing.Ready() {
+		b.narrate(fmt.Sprintf("%s does not answer yet, %s is charged %d/%d.", patron.Name, blessing.Name, blessing.Charge, blessing.Needed))
+		return
+	}
+	blessing.Charge = 0
+	b.log(patron.bless(b))
+}
+
+// chargeBlessing charges the hero's blessing for a turn fought
+func (b *Battle) chargeBlessing() {
+	if blessing := b.Hero.Blessing; blessing != nil {
+		blessing.Charge = min(blessing.Charge+1, blessing.Needed)
+	}
+}
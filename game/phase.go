@@ -0,0 +1,74 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	model "gladiator-sim/models"
+)
+
+// enterPhases moves a wounded boss into every phase whose threshold it has dropped
+// below and returns the announcements. The dead do not change phase.
+func enterPhases(p *model.Player) []model.LogEntry {
+	entries := []model.LogEntry{}
+	for len(p.Phases) > 0 && p.Health > 0 && p.Health*100 < p.MaxHealth*p.Phases[0].Below {
+		phase := p.Phases[0]
+		p.Phases = p.Phases[1:]
+		p.Phase = phase.Name
+
+		p.AddBuff(model.Buff{
+			Name:   phase.Name,
+			Source: phase.Name,
+			Scope:  model.BuffBattle,
+			Mods:   phase.Mods,
+		})
+		for _, hook := range phase.Hooks {
+			p.AddHook(hook)
+		}
+
+		entries = append(entries, model.LogEntry{
+			Kind:  model.LogPhase,
+			Actor: p.Name,
+			Text:  phase.Announce,
+		})
+
+		if phase.Heal > 0 {
+			healed := min(p.MaxHealth*phase.Heal/100, p.MaxHealth-p.Health)
+			p.Health += healed
+			entries = append(entries, model.LogEntry{
+				Kind:   model.LogRegen,
+				Actor:  p.Name,
+				Amount: healed,
+				Text:   fmt.Sprintf("%s recovers %d HP!", p.Name, healed),
+			})
+		}
+	}
+	return entries
+}
+
+// validatePhases checks that phases start at descending thresholds and only use hooks that exist
+func validatePhases(phases []model.Phase) error {
+	previous := 100
+	for _, phase := range phases {
+		if phase.Name == "" {
+			return errors.New("phase without a name")
+		}
+		if phase.Below < 1 || phase.Below >= previous {
+			return fmt.Errorf("phase %q must start below a threshold between 1 and %d, got %d", phase.Name, previous-1, phase.Below)
+		}
+		previous = phase.Below
+
+		if phase.Announce == "" {
+			return fmt.Errorf("phase %q needs an announcement", phase.Name)
+		}
+		if phase.Heal < 0 || phase.Heal > 100 {
+			return fmt.Errorf("phase %q: heal must be between 0 and 100, got %d", phase.Name, phase.Heal)
+		}
+		for _, hook := range phase.Hooks {
+			if _, ok := hookRegistry[hook]; !ok {
+				return fmt.Errorf("phase %q: unknown hook %q", phase.Name, hook)
+			}
+		}
+	}
+	return nil
+}
@@ -2,39 +2,25 @@ package game
 
 import model "gladiator-sim/models"
 
-// Default stats for Player Character
-var heroDefaultStats = model.Player{
-	Wins:         0,
-	IsHero:       true,
-	Health:       130,
-	MaxHealth:    130,
-	AttackMin:    10,
-	AttackMax:    15,
-	Defense:      1,
-	CritChance:   10,
-	BlockChance:  10,
-	LifeSteal:    0,
-	CritDamage:   0,
-	Regeneration: 0,
-}
-
-// NewHero creates a new player character
-func NewHero(playerName string) *model.Player {
-	hero := heroDefaultStats
-	hero.Name = playerName
+// NewHero creates a new player character of the given class pledged to the patron god,
+// with the starting bonuses bought in the shop. Locked classes fall back to the default
+// class, an unknown or empty patron leaves the hero without one.
+func (h *GameHandler) NewHero(playerName, className, patronName string) *model.Player {
+	if !h.IsClassUnlocked(className) {
+		className = heroClasses[0].Name
+	}
+	hero := heroClass(className).startingHero(playerName)
+	if patron, ok := FindPatron(patronName); ok {
+		patron.pledge(&hero)
+	}
+	h.applyBonuses(&hero)
 	return &hero
 }
 
-// ResetHero resets the hero to starting stats
+// ResetHero resets the hero to the starting stats of its class
 func (h *GameHandler) ResetHero(hero *model.Player) {
-	// keep old name
-	name := hero.Name
-
-	// reset hero stats
-	*hero = heroDefaultStats
-
-	// set old name
-	hero.Name = name
+	// keep old name, class and patron, reset everything else
+	*hero = *h.NewHero(hero.Name, hero.Class, hero.Patron)
 }
 
 // HandleUpgrade applies an upgrade to the player
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	model "gladiator-sim/models"
+)
+
+// ProfileVersion is the version of the profile file format, files of other versions are rejected
+const ProfileVersion = 1
+
+// Profile is what the player keeps across runs: gold and everything bought with it
+type Profile struct {
+	Version  int            `json:"version"`
+	Gold     int            `json:"gold"`
+	Bonuses  map[string]int `json:"bonuses,omitempty"`  // Purchased levels of each starting bonus
+	Unlocked []string       `json:"unlocked,omitempty"` // Upgrades and classes bought in the shop
+	// Deepest level past the final boss an endless run has reached
+	BestDepth int `json:"best_depth,omitempty"`
+}
+
+// NewProfile creates an empty profile
+func NewProfile() *Profile {
+	return &Profile{Version: ProfileVersion, Bonuses: map[string]int{}}
+}
+
+// IsUnlocked reports whether the upgrade or class with the given name was bought
+func (p *Profile) IsUnlocked(name string) bool {
+	return slices.Contains(p.Unlocked, name)
+}
+
+// ProfilePath returns where the player's profile is kept
+func ProfilePath() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profile.json"), nil
+}
+
+// LoadProfile reads the profile at path, a missing file is a new player with an empty profile
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewProfile(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading profile: %w", err)
+	}
+
+	profile := NewProfile()
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("decoding profile: %w", err)
+	}
+	if profile.Version != ProfileVersion {
+		return nil, fmt.Errorf("profile version %d is not supported (expected %d)", profile.Version, ProfileVersion)
+	}
+	if profile.Bonuses == nil {
+		profile.Bonuses = map[string]int{}
+	}
+	return profile, nil
+}
+
+// SaveProfile writes the profile to path, replacing the previous one
+func SaveProfile(path string, profile *Profile) error {
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding profile: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating profile directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never costs the player their gold
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing profile: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing profile: %w", err)
+	}
+	return nil
+}
+
+// saveProfile writes the handler's profile when it has a path, failures are noted in the battle log
+func (h *GameHandler) saveProfile(state *model.GameState) {
+	if h.ProfilePath == "" || h.Profile == nil {
+		return
+	}
+	if err := SaveProfile(h.ProfilePath, h.Profile); err != nil {
+		state.Narrate("⚠ Could not save your profile: " + err.Error())
+	}
+}
+
+// isUnlocked reports whether something with an unlock price may be used. Without a
+// profile, as in simulations, everything is available.
+func (h *GameHandler) isUnlocked(name string, price int) bool {
+	return price == 0 || h.Profile == nil || h.Profile.IsUnlocked(name)
+}
+
+// goldForWin returns the gold a victory over the enemy at level is worth
+func goldForWin(level int, isBoss bool) int {
+	gold := 10 + level*2
+	if isBoss {
+		gold *= 3
+	}
+	return gold
+}
+
+// awardGold adds the gold of a victory to the profile and notes it in the battle log
+func (h *GameHandler) awardGold(state *model.GameState, hero *model.Player, gold int) model.LogEntry {
+	state.GoldEarned += gold
+	h.Profile.Gold += gold
+	h.saveProfile(state)
+	return model.LogEntry{
+		Kind:   model.LogUpgrade,
+		Actor:  hero.Name,
+		Amount: gold,
+		Text:   fmt.Sprintf("💰 +%d gold (%d total)", gold, h.Profile.Gold),
+	}
+}
@@ -0,0 +1,195 @@
+package game
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	model "gladiator-sim/models"
+)
+
+// defaultRosterJSON is the enemy roster the game ships with
+//
+//go:embed content/enemies.json
+var defaultRosterJSON []byte
+
+// EnemyRoster is the lineup of a run: regular enemies fought in order, followed by the bosses
+type EnemyRoster struct {
+	Enemies []EnemyType `json:"enemies"`
+	Bosses  []EnemyType `json:"bosses"`
+}
+
+// Levels returns the number of battles needed to win a run
+func (r *EnemyRoster) Levels() int {
+	return len(r.Enemies) + len(r.Bosses)
+}
+
+// IsBossLevel reports whether the enemy at level is a boss
+func (r *EnemyRoster) IsBossLevel(level int) bool {
+	return level > len(r.Enemies)
+}
+
+// At returns the enemy type fought at level, starting at 1. Levels past the
+// end of the roster repeat the last boss.
+func (r *EnemyRoster) At(level int) EnemyType {
+	if !r.IsBossLevel(level) {
+		return r.Enemies[max(level, 1)-1]
+	}
+	return r.Bosses[min(level-len(r.Enemies), len(r.Bosses))-1]
+}
+
+var (
+	defaultRoster     *EnemyRoster
+	defaultRosterOnce sync.Once
+)
+
+// DefaultEnemyRoster returns the embedded enemy roster
+func DefaultEnemyRoster() *EnemyRoster {
+	// Parsed lazily, validation needs the hooks registered during package initialization
+	defaultRosterOnce.Do(func() {
+		roster, err := ParseEnemyRoster(defaultRosterJSON)
+		if err != nil {
+			panic(fmt.Sprintf("embedded enemy roster is invalid: %v", err))
+		}
+		defaultRoster = roster
+	})
+	return defaultRoster
+}
+
+// LoadEnemyRoster reads and validates an enemy roster file
+func LoadEnemyRoster(path string) (*EnemyRoster, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading enemy roster: %w", err)
+	}
+	roster, err := ParseEnemyRoster(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return roster, nil
+}
+
+// ParseEnemyRoster decodes and validates an enemy roster from JSON
+func ParseEnemyRoster(data []byte) (*EnemyRoster, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Typos in field names would silently fall back to zero otherwise
+	decoder.DisallowUnknownFields()
+
+	var roster EnemyRoster
+	if err := decoder.Decode(&roster); err != nil {
+		return nil, fmt.Errorf("decoding enemy roster: %w", err)
+	}
+	if err := roster.validate(); err != nil {
+		return nil, err
+	}
+	return &roster, nil
+}
+
+// validate checks that the roster is complete and every enemy is within sane ranges
+func (r *EnemyRoster) validate() error {
+	if len(r.Enemies) == 0 {
+		return errors.New("enemy roster needs at least one enemy")
+	}
+	if len(r.Bosses) == 0 {
+		return errors.New("enemy roster needs at least one boss")
+	}
+
+	names := map[string]bool{}
+	for _, enemy := range append(append([]EnemyType{}, r.Enemies...), r.Bosses...) {
+		if enemy.Name == "" {
+			return errors.New("enemy without a name")
+		}
+		if names[enemy.Name] {
+			return fmt.Errorf("enemy %q is defined twice", enemy.Name)
+		}
+		names[enemy.Name] = true
+
+		if err := enemy.validate(); err != nil {
+			return fmt.Errorf("enemy %q: %w", enemy.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks the modifiers and chances of a single enemy type
+func (e EnemyType) validate() error {
+	mods := []struct {
+		name  string
+		value float64
+	}{
+		{"health_mod", e.HealthMod},
+		{"attack_mod", e.AttackMod},
+		{"defense_mod", e.DefenseMod},
+	}
+	for _, mod := range mods {
+		if mod.value <= 0 || mod.value > 5 {
+			return fmt.Errorf("%s must be above 0 and at most 5, got %g", mod.name, mod.value)
+		}
+	}
+
+	percentages := []struct {
+		name  string
+		value int
+	}{
+		{"crit_chance", e.CritChance},
+		{"block_chance", e.BlockChance},
+		{"life_steal", e.LifeSteal},
+		{"regeneration", e.Regeneration},
+		{"evasion", e.Evasion},
+	}
+	for _, p := range percentages {
+		if p.value < 0 || p.value > 100 {
+			return fmt.Errorf("%s must be between 0 and 100, got %d", p.name, p.value)
+		}
+	}
+
+	if e.DamageType != "" && !slices.Contains(model.DamageTypes, e.DamageType) {
+		return fmt.Errorf("unknown damage type %q", e.DamageType)
+	}
+	for _, damageType := range model.DamageTypes {
+		if r := e.Resistances.Get(damageType); r < -100 || r > maxResistance {
+			return fmt.Errorf("%s resistance must be between -100 and %d, got %d", damageType, maxResistance, r)
+		}
+	}
+
+	if e.Speed < 0 {
+		return fmt.Errorf("speed must not be negative, got %d", e.Speed)
+	}
+
+	if e.CritDamage < 0 {
+		return fmt.Errorf("crit_damage must not be negative, got %d", e.CritDamage)
+	}
+
+	for _, hook := range e.Hooks {
+		if _, ok := hookRegistry[hook]; !ok {
+			return fmt.Errorf("unknown hook %q", hook)
+		}
+	}
+
+	if err := validatePhases(e.Phases); err != nil {
+		return err
+	}
+
+	for _, drop := range e.Loot {
+		if _, ok := DefaultItemCatalog().Find(drop.Item); !ok {
+			return fmt.Errorf("drops unknown item %q", drop.Item)
+		}
+		if drop.Chance < 1 || drop.Chance > 100 {
+			return fmt.Errorf("loot chance of %q must be between 1 and 100, got %d", drop.Item, drop.Chance)
+		}
+	}
+	return nil
+}
+
+// roster returns the handler's enemy roster, falling back to the embedded one
+func (h *GameHandler) roster() *EnemyRoster {
+	if h.Enemies != nil {
+		return h.Enemies
+	}
+	return DefaultEnemyRoster()
+}
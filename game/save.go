@@ -0,0 +1,170 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	model "gladiator-sim/models"
+)
+
+// SaveVersion is the version of the save file format, files of other versions are rejected.
+// Bump it whenever the saved hero, enemy or state changes shape, older files would load
+// the new fields as zero values.
+//
+// History since version 3 (equipment):
+//   - 4: consumables in the hero's inventory
+//   - 5: gold earned by the run
+//   - 6: endless mode
+//   - 7: elite flag and affixes of enemies
+//   - 8: boss phases
+//   - 9: tactical mode
+//   - 10: speed stat
+//   - 11: evasion stat
+//   - 12: damage types, resistances and extra typed damage
+//   - 13: status effects
+//   - 14: arena condition of the battle
+//   - 15: crowd favor and demand
+//   - 16: patron and blessing of the hero
+const SaveVersion = 16
+
+// saveFile is the on-disk representation of a run in progress
+type saveFile struct {
+	Version  int              `json:"version"`
+	Hero     *model.Player    `json:"hero"`
+	Enemy    *model.Player    `json:"enemy"` // Last opponent, shown until the next battle starts
+	State    *model.GameState `json:"state"`
+	RngDraws uint64           `json:"rng_draws"`
+}
+
+// DataDir returns the directory the game keeps its files in, following the XDG base directory spec
+func DataDir() (string, error) {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locating data directory: %w", err)
+		}
+		base = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(base, "gladiator-sim"), nil
+}
+
+// SavePath returns where the current run is saved
+func SavePath() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "save.json"), nil
+}
+
+// HasSave reports whether a saved run exists at path
+func HasSave(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// SaveRun writes the run to path, replacing any previous save
+func SaveRun(path string, hero, enemy *model.Player, state *model.GameState) error {
+	data, err := json.MarshalIndent(saveFile{
+		Version:  SaveVersion,
+		Hero:     hero,
+		Enemy:    enemy,
+		State:    state,
+		RngDraws: state.RngSource.Draws(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding save: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating save directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a half written save
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing save: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing save: %w", err)
+	}
+	return nil
+}
+
+// LoadRun reads a run saved by SaveRun and restores its random stream
+func (h *GameHandler) LoadRun(path string) (hero, enemy *model.Player, state *model.GameState, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("reading save: %w", err)
+	}
+
+	var save saveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding save: %w", err)
+	}
+	if save.Version != SaveVersion {
+		return nil, nil, nil, fmt.Errorf("save file version %d is not supported (expected %d)", save.Version, SaveVersion)
+	}
+	if save.Hero == nil || save.Enemy == nil || save.State == nil {
+		return nil, nil, nil, errors.New("save file is incomplete")
+	}
+
+	state = save.State
+	state.RngSource = model.RestoreRandSource(state.Seed, save.RngDraws)
+	state.Rng = rand.New(state.RngSource)
+
+	// Upgrade effects are code, so offered upgrades are rebound by name
+	for i, offered := range state.Upgrades {
+		if offered.IsLoot() {
+			item, ok := DefaultItemCatalog().Find(offered.Item)
+			if !ok {
+				return nil, nil, nil, fmt.Errorf("save file offers unknown item %q", offered.Item)
+			}
+			state.Upgrades[i] = offerLoot(save.Hero, item)
+			continue
+		}
+		upgrade, ok := h.upgrades().Find(offered.Name)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("save file offers unknown upgrade %q", offered.Name)
+		}
+		state.Upgrades[i] = offerUpgrade(state.Rng, save.Hero, upgrade)
+	}
+
+	return save.Hero, save.Enemy, state, nil
+}
+
+// DeleteSave removes the save at path, a missing save is not an error
+func DeleteSave(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting save: %w", err)
+	}
+	return nil
+}
+
+// autoSave saves the run when the handler has a save path, failures are noted in the battle log
+func (h *GameHandler) autoSave(hero, enemy *model.Player, state *model.GameState) {
+	if h.SavePath == "" {
+		return
+	}
+	if err := SaveRun(h.SavePath, hero, enemy, state); err != nil {
+		state.Narrate("⚠ Could not save the run: " + err.Error())
+	}
+}
+
+// clearSave removes the save of a finished run, failures are noted in the battle log
+func (h *GameHandler) clearSave(state *model.GameState) {
+	if err := DeleteSave(h.SavePath); err != nil {
+		state.Narrate("⚠ " + err.Error())
+	}
+}
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	model "gladiator-sim/models"
+	"gladiator-sim/ui"
+)
+
+// startingBonus is a permanent improvement of the starting stats bought in the shop
+type startingBonus struct {
+	Name        string
+	Description string
+	Price       int // Price of the first level, every further level costs this much more
+	MaxLevel    int
+	Apply       func(p *model.Player, level int)
+}
+
+var startingBonuses = []startingBonus{
+	{
+		Name:        "Toughness",
+		Description: "+10 starting Max HP",
+		Price:       40,
+		MaxLevel:    5,
+		Apply: func(p *model.Player, level int) {
+			p.MaxHealth += 10 * level
+			p.Health += 10 * level
+		},
+	},
+	{
+		Name:        "Weapon Drills",
+		Description: "+1 starting Min and Max ATK",
+		Price:       50,
+		MaxLevel:    5,
+		Apply: func(p *model.Player, level int) {
+			p.AttackMin += level
+			p.AttackMax += level
+		},
+	},
+	{
+		Name:        "Conditioning",
+		Description: "+1 starting DEF",
+		Price:       60,
+		MaxLevel:    3,
+		Apply: func(p *model.Player, level int) {
+			p.Defense += level
+		},
+	},
+	{
+		Name:        "Supply Deal",
+		Description: "+1 starting Healing Draught",
+		Price:       50,
+		MaxLevel:    2,
+		Apply: func(p *model.Player, level int) {
+			p.AddConsumable(model.HealingDraught, level)
+		},
+	},
+}
+
+// applyBonuses gives a fresh hero the starting bonuses bought with the profile
+func (h *GameHandler) applyBonuses(hero *model.Player) {
+	if h.Profile == nil {
+		return
+	}
+	for _, bonus := range startingBonuses {
+		if level := h.Profile.Bonuses[bonus.Name]; level > 0 {
+			bonus.Apply(hero, level)
+		}
+	}
+}
+
+// shopEntry is something for sale, together with what buying it does to the profile
+type shopEntry struct {
+	offer ui.ShopOffer
+	buy   func(p *Profile)
+}
+
+// shopEntries lists everything the shop sells, in display order
+func (h *GameHandler) shopEntries() []shopEntry {
+	entries := []shopEntry{}
+
+	for _, bonus := range startingBonuses {
+		level := h.Profile.Bonuses[bonus.Name]
+		entries = append(entries, shopEntry{
+			offer: ui.ShopOffer{
+				Name:        fmt.Sprintf("%s (%d/%d)", bonus.Name, level, bonus.MaxLevel),
+				Description: bonus.Description,
+				Price:       bonus.Price * (level + 1),
+				SoldOut:     level >= bonus.MaxLevel,
+			},
+			buy: func(p *Profile) {
+				p.Bonuses[bonus.Name]++
+			},
+		})
+	}
+
+	for _, upgrade := range h.upgrades().Types() {
+		if upgrade.UnlockPrice > 0 {
+			entries = append(entries, unlockEntry(h.Profile, "Upgrade: "+upgrade.Name, upgrade.Description, upgrade.Name, upgrade.UnlockPrice))
+		}
+	}
+
+	for _, class := range heroClasses {
+		if class.UnlockPrice > 0 {
+			entries = append(entries, unlockEntry(h.Profile, "Class: "+class.Name, class.Description, class.Name, class.UnlockPrice))
+		}
+	}
+
+	return entries
+}
+
+// unlockEntry creates the shop entry unlocking an upgrade or class for good
+func unlockEntry(profile *Profile, title, description, name string, price int) shopEntry {
+	return shopEntry{
+		offer: ui.ShopOffer{
+			Name:        title,
+			Description: description,
+			Price:       price,
+			SoldOut:     profile.IsUnlocked(name),
+		},
+		buy: func(p *Profile) {
+			p.Unlocked = append(p.Unlocked, name)
+		},
+	}
+}
+
+// ShopOffers returns the gold of the profile and everything the shop sells
+func (h *GameHandler) ShopOffers() (int, []ui.ShopOffer) {
+	if h.Profile == nil {
+		return 0, nil
+	}
+	offers := []ui.ShopOffer{}
+	for _, entry := range h.shopEntries() {
+		offers = append(offers, entry.offer)
+	}
+	return h.Profile.Gold, offers
+}
+
+// Buy purchases the shop offer at index and saves the profile
+func (h *GameHandler) Buy(index int) error {
+	if h.Profile == nil {
+		return errors.New("the shop is closed without a profile")
+	}
+	entries := h.shopEntries()
+	if index < 0 || index >= len(entries) {
+		return errors.New("nothing for sale there")
+	}
+
+	entry := entries[index]
+	switch {
+	case entry.offer.SoldOut:
+		return errors.New("you already own that")
+	case entry.offer.Price > h.Profile.Gold:
+		return fmt.Errorf("you need %d more gold", entry.offer.Price-h.Profile.Gold)
+	}
+
+	h.Profile.Gold -= entry.offer.Price
+	entry.buy(h.Profile)
+
+	if h.ProfilePath != "" {
+		if err := SaveProfile(h.ProfilePath, h.Profile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClassOptions lists the classes unlocked so far as menu entries, with their passives
+func (h *GameHandler) ClassOptions() []ui.MenuOption {
+	options := []ui.MenuOption{}
+	for _, class := range heroClasses {
+		if !h.IsClassUnlocked(class.Name) {
+			continue
+		}
+		options = append(options, ui.MenuOption{
+			Name:        class.Name,
+			Description: fmt.Sprintf("%s. %s: %s", class.Description, class.Passive, class.PassiveText),
+		})
+	}
+	return options
+}
+
+// IsClassUnlocked reports whether the class can be picked for a new run
+func (h *GameHandler) IsClassUnlocked(name string) bool {
+	class, ok := FindHeroClass(name)
+	return ok && h.isUnlocked(class.Name, class.UnlockPrice)
+}
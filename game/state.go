@@ -1,25 +1,44 @@
 package game
 
-import model "gladiator-sim/models"
+import (
+	"math/rand"
 
-// NewGameState creates a new game state
-func NewGameState() *model.GameState {
-	return &model.GameState{
+	model "gladiator-sim/models"
+)
+
+// NewGameState creates a new game state whose random rolls are driven by seed
+func NewGameState(seed int64) *model.GameState {
+	state := &model.GameState{
 		CurrentEnemy:    1,
 		UpgradeMode:     false,
 		SelectedUpgrade: 0,
-		BattleLog:       []string{},
+		BattleLog:       []model.LogEntry{},
 		GameOver:        false,
 	}
+	seedRng(state, seed)
+	return state
 }
 
 // ResetGameState resets the game state to starting values
 func (h *GameHandler) ResetGameState(state *model.GameState) {
 	state.CurrentEnemy = 1
 	state.GameOver = false
+	state.Endless = false
 	state.UpgradeMode = false
-	state.BattleLog = []string{}
+	state.BattleLog = []model.LogEntry{}
 	state.SelectedUpgrade = 0
+	state.GoldEarned = 0
+	state.Favor = 0
+	state.Demand = nil
+
+	// Every run gets its own seed, derived from the previous run so a whole
+	// session stays reproducible from the initial --seed
+	seedRng(state, state.Rng.Int63())
+}
 
-	ResetUpgradeTracker()
+// seedRng gives the run a fresh random generator starting at seed
+func seedRng(state *model.GameState, seed int64) {
+	state.Seed = seed
+	state.RngSource = model.NewRandSource(seed)
+	state.Rng = rand.New(state.RngSource)
 }
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+
+	model "gladiator-sim/models"
+)
+
+// statusEffect describes how a kind of status behaves
+type statusEffect struct {
+	Damage     int              // Damage per stack at the start of each of the owner's turns
+	DamageType model.DamageType // Type of that damage, resistances reduce it
+	MaxStacks  int
+	SkipsTurn  bool // The owner loses its turn while the status lasts
+}
+
+var statusEffects = map[model.StatusKind]statusEffect{
+	model.StatusBleed:    {Damage: 2, DamageType: model.DamageBleed, MaxStacks: 3},
+	model.StatusPoison:   {Damage: 1, DamageType: model.DamagePoison, MaxStacks: 10},
+	model.StatusBurn:     {Damage: 4, DamageType: model.DamageFire, MaxStacks: 3},
+	model.StatusStun:     {MaxStacks: 1, SkipsTurn: true},
+	model.StatusGrievous: {MaxStacks: 1},
+}
+
+// Names of the hooks that inflict status effects
+const (
+	lacerate    = "Lacerate"
+	venom       = "Venom"
+	searing     = "Searing"
+	concussion  = "Concussion"
+	cruelWounds = "Cruel Wounds"
+	// Percent of healing a player with grievous wounds still receives
+	grievousHealing = 50
+)
+
+func init() {
+	// Unblocked hits open a bleeding wound
+	RegisterHook(lacerate, HookFuncs{Hit: func(ctx *HitContext) {
+		if ctx.IsAttacker() && !ctx.IsBlocked {
+			ctx.Log(inflict(ctx.Defender, model.StatusBleed, 1, 2))
+		}
+	}})
+
+	// Every hit that lands adds poison, blocked or not
+	RegisterHook(venom, HookFuncs{Hit: func(ctx *HitContext) {
+		if ctx.IsAttacker() {
+			ctx.Log(inflict(ctx.Defender, model.StatusPoison, 2, 4))
+		}
+	}})
+
+	// Critical hits set the defender on fire
+	RegisterHook(searing, HookFuncs{Crit: func(ctx *HitContext) {
+		if ctx.IsAttacker() {
+			ctx.Log(inflict(ctx.Defender, model.StatusBurn, 1, 2))
+		}
+	}})
+
+	// Critical hits stun the defender, who cannot be stunned again while still reeling
+	RegisterHook(concussion, HookFuncs{Crit: func(ctx *HitContext) {
+		if ctx.IsAttacker() && !ctx.Defender.HasStatus(model.StatusStun) {
+			ctx.Log(inflict(ctx.Defender, model.StatusStun, 1, 1))
+		}
+	}})
+
+	// Unblocked hits leave wounds that heal poorly
+	RegisterHook(cruelWounds, HookFuncs{Hit: func(ctx *HitContext) {
+		if ctx.IsAttacker() && !ctx.IsBlocked {
+			ctx.Log(inflict(ctx.Defender, model.StatusGrievous, 1, 2))
+		}
+	}})
+}
+
+// inflict adds a status to the target and returns the log entry announcing it
+func inflict(target *model.Player, kind model.StatusKind, stacks, turns int) model.LogEntry {
+	target.AddStatus(kind, stacks, turns, statusEffects[kind].MaxStacks)
+
+	text := fmt.Sprintf("%s %s is %s!", kind.Icon(), target.Name, kind)
+	if status := target.GetStatus(kind); status.Stacks > 1 {
+		text += fmt.Sprintf(" (x%d)", status.Stacks)
+	}
+	return model.LogEntry{Kind: model.LogStatus, Target: target.Name, Text: text}
+}
+
+// healing returns how much of amount a player actually heals, grievous wounds halve it
+func healing(p *model.Player, amount int) int {
+	if p.HasStatus(model.StatusGrievous) {
+		return amount * grievousHealing / 100
+	}
+	return amount
+}
+
+// tickStatuses lets the statuses of the player whose turn starts take effect. It reports
+// whether the player loses the turn and whether damage over time ended the battle.
+func (b *Battle) tickStatuses(owner, opponent *model.Player) (skipTurn, isGameOver bool) {
+	for _, status := range owner.Statuses {
+		effect := statusEffects[status.Kind]
+
+		if effect.Damage > 0 {
+			damage := max(1, resist(effect.Damage*status.Stacks, owner, effect.DamageType))
+			ctx := &HitContext{Rng: b.State.Rng, Attacker: opponent, Defender: owner, Damage: damage, Arena: b.State.Arena}
+			ctx.applyDamage()
+			b.log(model.LogEntry{
+				Kind:   model.LogStatus,
+				Actor:  owner.Name,
+				Amount: damage,
+				Text:   fmt.Sprintf("%s %s is %s and takes %d %s damage.", status.Kind.Icon(), owner.Name, status.Kind, damage, effect.DamageType),
+				Types:  []model.DamageType{effect.DamageType},
+			})
+			for _, entry := range enterPhases(owner) {
+				b.log(entry)
+			}
+
+			// A revive keeps the battle going, the remaining statuses still tick
+			winner := resolveDeath(ctx, opponent, owner)
+			for _, entry := range ctx.Events {
+				b.log(entry)
+			}
+			b.trackDemand(model.BattleResult{
+				Attacker:   opponent,
+				Defender:   owner,
+				Damage:     damage,
+				Types:      []model.DamageType{effect.DamageType},
+				IsGameOver: winner != nil,
+			})
+			if winner != nil {
+				return true, true
+			}
+		}
+
+		if effect.SkipsTurn {
+			skipTurn = true
+			b.log(model.LogEntry{
+				Kind:  model.LogStatus,
+				Actor: owner.Name,
+				Text:  fmt.Sprintf("%s %s is %s and loses the turn!", status.Kind.Icon(), owner.Name, status.Kind),
+			})
+		}
+	}
+
+	for _, status := range owner.TickStatuses() {
+		b.log(model.LogEntry{
+			Kind:  model.LogStatus,
+			Actor: owner.Name,
+			Text:  fmt.Sprintf("%s is no longer %s.", owner.Name, status.Kind),
+		})
+	}
+	return skipTurn, false
+}
@@ -0,0 +1,364 @@
+package game
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+
+	model "gladiator-sim/models"
+)
+
+// defaultUpgradesJSON is the upgrade catalog the game ships with
+//
+//go:embed content/upgrades.json
+var defaultUpgradesJSON []byte
+
+// unlimitedLevel is the max level of upgrades that can be taken any number of times
+const unlimitedLevel = 999
+
+// UpgradeDef is the declarative form of an upgrade as stored in content files
+type UpgradeDef struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Rarity      int              `json:"rarity"`                // Higher rarity means less common (1-3)
+	MaxLevel    int              `json:"max_level,omitempty"`   // 0 means unlimited
+	Add         map[string]int   `json:"add,omitempty"`         // Flat stat changes, keyed by stat name
+	Percent     map[string]int   `json:"percent,omitempty"`     // Stat changes in percent of the current value
+	Hooks       []string         `json:"hooks,omitempty"`       // Combat hooks the hero gains
+	DamageType  model.DamageType `json:"damage_type,omitempty"` // Converts the hero's weapon attacks to this type
+	Requires    Requirements     `json:"requires,omitempty"`
+	Class       string           `json:"class,omitempty"`        // Only offered to heroes of this class
+	Code        bool             `json:"code,omitempty"`         // Effect or availability is implemented in upgradeSpecials
+	UnlockPrice int              `json:"unlock_price,omitempty"` // Gold to unlock it in the shop, 0 means always offered
+}
+
+// Requirements are the prerequisites an upgrade needs before it is offered
+type Requirements struct {
+	Upgrades map[string]int       `json:"upgrades,omitempty"` // Minimum level of other upgrades
+	Stats    map[string]StatRange `json:"stats,omitempty"`    // Allowed range of the hero's base stats
+}
+
+// StatRange bounds a stat, unset bounds are open
+type StatRange struct {
+	Min *int `json:"min,omitempty"`
+	Max *int `json:"max,omitempty"`
+}
+
+// UpgradeCatalog is the set of upgrades that can be offered during a run
+type UpgradeCatalog struct {
+	Upgrades []UpgradeDef `json:"upgrades"`
+
+	types []UpgradeType
+}
+
+// Types returns the playable upgrades of the catalog
+func (c *UpgradeCatalog) Types() []UpgradeType {
+	return c.types
+}
+
+// Find returns the upgrade with the given name
+func (c *UpgradeCatalog) Find(name string) (UpgradeType, bool) {
+	for _, upgrade := range c.types {
+		if upgrade.Name == name {
+			return upgrade, true
+		}
+	}
+	return UpgradeType{}, false
+}
+
+var (
+	defaultUpgrades     *UpgradeCatalog
+	defaultUpgradesOnce sync.Once
+)
+
+// DefaultUpgradeCatalog returns the embedded upgrade catalog
+func DefaultUpgradeCatalog() *UpgradeCatalog {
+	// Parsed lazily, validation needs the hooks registered during package initialization
+	defaultUpgradesOnce.Do(func() {
+		catalog, err := ParseUpgradeCatalog(defaultUpgradesJSON)
+		if err != nil {
+			panic(fmt.Sprintf("embedded upgrade catalog is invalid: %v", err))
+		}
+		defaultUpgrades = catalog
+	})
+	return defaultUpgrades
+}
+
+// LoadUpgradeCatalog reads and validates an upgrade catalog file
+func LoadUpgradeCatalog(path string) (*UpgradeCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading upgrades: %w", err)
+	}
+	catalog, err := ParseUpgradeCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// ParseUpgradeCatalog decodes and validates an upgrade catalog from JSON
+func ParseUpgradeCatalog(data []byte) (*UpgradeCatalog, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Typos in field names would silently fall back to zero otherwise
+	decoder.DisallowUnknownFields()
+
+	var catalog UpgradeCatalog
+	if err := decoder.Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("decoding upgrades: %w", err)
+	}
+	if err := catalog.validate(); err != nil {
+		return nil, err
+	}
+
+	for _, def := range catalog.Upgrades {
+		catalog.types = append(catalog.types, def.build())
+	}
+	return &catalog, nil
+}
+
+// validate checks that every upgrade is complete and only refers to things that exist
+func (c *UpgradeCatalog) validate() error {
+	if len(c.Upgrades) == 0 {
+		return errors.New("upgrade catalog is empty")
+	}
+
+	names := map[string]bool{}
+	for _, def := range c.Upgrades {
+		if def.Name == "" {
+			return errors.New("upgrade without a name")
+		}
+		if names[def.Name] {
+			return fmt.Errorf("upgrade %q is defined twice", def.Name)
+		}
+		names[def.Name] = true
+	}
+
+	for _, def := range c.Upgrades {
+		if err := def.validate(names); err != nil {
+			return fmt.Errorf("upgrade %q: %w", def.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a single upgrade against the names of all upgrades in its catalog
+func (d UpgradeDef) validate(upgrades map[string]bool) error {
+	if d.Rarity < 1 || d.Rarity > 3 {
+		return fmt.Errorf("rarity must be between 1 and 3, got %d", d.Rarity)
+	}
+	if d.UnlockPrice < 0 {
+		return fmt.Errorf("unlock_price must not be negative, got %d", d.UnlockPrice)
+	}
+	if d.MaxLevel < 0 {
+		return fmt.Errorf("max_level must not be negative, got %d", d.MaxLevel)
+	}
+
+	if _, ok := FindHeroClass(d.Class); d.Class != "" && !ok {
+		return fmt.Errorf("unknown class %q", d.Class)
+	}
+
+	_, hasSpecial := upgradeSpecials[d.Name]
+	if d.Code && !hasSpecial {
+		return errors.New("marked as code but has no code implementation")
+	}
+	if !d.Code && len(d.Add) == 0 && len(d.Percent) == 0 && len(d.Hooks) == 0 && d.DamageType == "" {
+		return errors.New("has no effect")
+	}
+	if d.DamageType != "" && !slices.Contains(model.DamageTypes, d.DamageType) {
+		return fmt.Errorf("unknown damage type %q", d.DamageType)
+	}
+
+	for _, stats := range []map[string]int{d.Add, d.Percent} {
+		for stat := range stats {
+			if !isStat(stat) {
+				return fmt.Errorf("unknown stat %q", stat)
+			}
+		}
+	}
+	for stat, r := range d.Requires.Stats {
+		if !isStat(stat) {
+			return fmt.Errorf("requires unknown stat %q", stat)
+		}
+		if r.Min == nil && r.Max == nil {
+			return fmt.Errorf("requirement on %q needs a min or max", stat)
+		}
+	}
+	for upgrade := range d.Requires.Upgrades {
+		if !upgrades[upgrade] {
+			return fmt.Errorf("requires unknown upgrade %q", upgrade)
+		}
+	}
+	for _, hook := range d.Hooks {
+		if _, ok := hookRegistry[hook]; !ok {
+			return fmt.Errorf("unknown hook %q", hook)
+		}
+	}
+	return nil
+}
+
+// build turns the definition into a playable upgrade
+func (d UpgradeDef) build() UpgradeType {
+	special := upgradeSpecials[d.Name]
+
+	maxLevel := d.MaxLevel
+	if maxLevel == 0 {
+		maxLevel = unlimitedLevel
+	}
+
+	return UpgradeType{
+		Name:        d.Name,
+		Description: d.Description,
+		MaxLevel:    maxLevel,
+		Rarity:      d.Rarity,
+		UnlockPrice: d.UnlockPrice,
+		Effect: func(p *model.Player, rng *rand.Rand) {
+			applyStatChanges(p, d.Add, d.Percent)
+			for _, hook := range d.Hooks {
+				p.AddHook(hook)
+			}
+			if d.DamageType != "" {
+				p.DamageType = d.DamageType
+			}
+			if special.Effect != nil {
+				special.Effect(p, rng)
+			}
+		},
+		IsAvailable: func(p *model.Player) bool {
+			if d.Class != "" && d.Class != p.Class {
+				return false
+			}
+			// Converting to the type the hero already deals would be a wasted pick
+			if d.DamageType != "" && d.DamageType == p.AttackType() {
+				return false
+			}
+			if !d.Requires.met(p) {
+				return false
+			}
+			return special.IsAvailable == nil || special.IsAvailable(p)
+		},
+	}
+}
+
+// met reports whether the hero fulfills all requirements
+func (r Requirements) met(p *model.Player) bool {
+	for upgrade, level := range r.Upgrades {
+		if GetUpgradeLevel(p, upgrade) < level {
+			return false
+		}
+	}
+	for stat, bounds := range r.Stats {
+		value := *statField(p, stat)
+		if bounds.Min != nil && value < *bounds.Min {
+			return false
+		}
+		if bounds.Max != nil && value > *bounds.Max {
+			return false
+		}
+	}
+	return true
+}
+
+// statNames are the stats content files can refer to, followed by
+// "<type>_resistance" and "<type>_damage" for every damage type
+var statNames = append([]string{
+	"health", "max_health", "attack_min", "attack_max", "defense", "crit_chance",
+	"block_chance", "life_steal", "crit_damage", "regeneration", "life_on_kill",
+	"speed", "evasion",
+}, typedStatNames()...)
+
+// typedStatNames returns the names of the resistance and extra damage stats of every damage type
+func typedStatNames() []string {
+	names := []string{}
+	for _, damageType := range model.DamageTypes {
+		names = append(names, string(damageType)+"_resistance", string(damageType)+"_damage")
+	}
+	return names
+}
+
+// isStat reports whether name is a known stat
+func isStat(name string) bool {
+	return slices.Contains(statNames, name)
+}
+
+// statField returns the base stat of the player with the given name
+func statField(p *model.Player, name string) *int {
+	switch name {
+	case "health":
+		return &p.Health
+	case "max_health":
+		return &p.MaxHealth
+	case "attack_min":
+		return &p.AttackMin
+	case "attack_max":
+		return &p.AttackMax
+	case "defense":
+		return &p.Defense
+	case "crit_chance":
+		return &p.CritChance
+	case "block_chance":
+		return &p.BlockChance
+	case "life_steal":
+		return &p.LifeSteal
+	case "crit_damage":
+		return &p.CritDamage
+	case "regeneration":
+		return &p.Regeneration
+	case "life_on_kill":
+		return &p.LifeOnKill
+	case "speed":
+		return &p.Speed
+	case "evasion":
+		return &p.Evasion
+	}
+	for _, damageType := range model.DamageTypes {
+		switch name {
+		case string(damageType) + "_resistance":
+			return p.Resistances.Of(damageType)
+		case string(damageType) + "_damage":
+			return p.ExtraDamage.Of(damageType)
+		}
+	}
+	panic(fmt.Sprintf("unknown stat %q", name))
+}
+
+// applyStatChanges adds flat and percentage changes to the player's stats. Health
+// stays within 1 and max health, so an upgrade can never kill the hero.
+func applyStatChanges(p *model.Player, add, percent map[string]int) {
+	for _, stat := range sortedKeys(add) {
+		*statField(p, stat) += add[stat]
+	}
+	for _, stat := range sortedKeys(percent) {
+		field := statField(p, stat)
+		*field += *field * percent[stat] / 100
+	}
+
+	if len(add) > 0 || len(percent) > 0 {
+		p.Health = max(1, min(p.Health, p.MaxHealth))
+	}
+}
+
+// sortedKeys returns the keys of m in a stable order
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// upgrades returns the handler's upgrade catalog, falling back to the embedded one
+func (h *GameHandler) upgrades() *UpgradeCatalog {
+	if h.Upgrades != nil {
+		return h.Upgrades
+	}
+	return DefaultUpgradeCatalog()
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"fmt"
+
+	model "gladiator-sim/models"
+)
+
+// Names of the hooks and buffs granted by conditional upgrades
+const (
+	adrenalinRush   = "Adrenalin Rush"
+	allesOderNix    = "ALLES ODER NIX"
+	adaptiveArmor   = "Adaptive Armor"
+	battleTrance    = "Battle Trance"
+	battleTrance2   = "Battle Trance II"
+	epicDieMove     = "Epic Die Move"
+	spikedArmor     = "Spiked Armor"
+	spikedShield    = "Spiked Shield"
+	firstStrike     = "First Strike"
+	tranceTurn      = 4  // Turn that starts after three completed turns
+	spikedShieldDmg = 10 // Damage dealt to the attacker on every block
+)
+
+func init() {
+	// More damage while at low health
+	RegisterHook(adrenalinRush, HookFuncs{Hit: func(ctx *HitContext) {
+		if !ctx.IsAttacker() || ctx.Owner.Health*100 >= ctx.Owner.MaxHealth*30 {
+			return
+		}
+		ctx.Damage += ctx.Damage / 2
+		ctx.Log(buffEntry(ctx.Owner, fmt.Sprintf("%s fights on pure adrenalin!", ctx.Owner.Name)))
+	}})
+
+	// Gamble every hit: triple damage or none at all
+	RegisterHook(allesOderNix, HookFuncs{Hit: func(ctx *HitContext) {
+		if !ctx.IsAttacker() {
+			return
+		}
+		switch ctx.Rng.Intn(4) {
+		case 0:
+			ctx.Damage *= 3
+			ctx.Log(buffEntry(ctx.Owner, "ALLES! The gamble pays off with triple damage!"))
+		case 1:
+			ctx.Damage = 0
+			ctx.Log(buffEntry(ctx.Owner, "NIX! The gamble leaves the strike without any force."))
+		}
+	}})
+
+	// Harden with every hit taken, until the battle ends
+	RegisterHook(adaptiveArmor, HookFuncs{Hit: func(ctx *HitContext) {
+		if ctx.IsAttacker() {
+			return
+		}
+		ctx.Owner.AddBuff(model.Buff{
+			Name:      adaptiveArmor,
+			Source:    adaptiveArmor,
+			MaxStacks: 10,
+			Scope:     model.BuffBattle,
+			Mods:      model.Modifiers{Defense: 1},
+		})
+	}})
+
+	// After three turns, a single turn of greatly increased crit chance
+	RegisterHook(battleTrance, HookFuncs{TurnStart: func(ctx *TurnContext) {
+		if ctx.OwnerTurn != tranceTurn {
+			return
+		}
+		ctx.Owner.AddBuff(model.Buff{
+			Name:   battleTrance,
+			Source: battleTrance,
+			Scope:  model.BuffTurns,
+			Turns:  1,
+			Mods:   model.Modifiers{CritChance: 50},
+		})
+		ctx.Log(buffEntry(ctx.Owner, fmt.Sprintf("%s enters a battle trance!", ctx.Owner.Name)))
+	}})
+
+	// After three turns, more attack for the rest of the battle
+	RegisterHook(battleTrance2, HookFuncs{TurnStart: func(ctx *TurnContext) {
+		if ctx.OwnerTurn != tranceTurn {
+			return
+		}
+		ctx.Owner.AddBuff(model.Buff{
+			Name:   battleTrance2,
+			Source: battleTrance2,
+			Scope:  model.BuffBattle,
+			Mods:   model.Modifiers{AttackMin: 10, AttackMax: 10},
+		})
+		ctx.Log(buffEntry(ctx.Owner, fmt.Sprintf("%s's trance deepens into fury!", ctx.Owner.Name)))
+	}})
+
+	// Reflect part of the damage taken back to the attacker
+	RegisterHook(spikedArmor, HookFuncs{Hit: func(ctx *HitContext) {
+		if ctx.IsAttacker() || ctx.Damage <= 0 {
+			return
+		}
+		reflected := max(1, ctx.Damage/10)
+		ctx.Reflect(reflected)
+		ctx.Log(model.LogEntry{
+			Kind:   model.LogBuff,
+			Actor:  ctx.Owner.Name,
+			Target: ctx.Attacker.Name,
+			Amount: reflected,
+			Text:   fmt.Sprintf("%s is cut by spiked armor for %d damage.", ctx.Attacker.Name, reflected),
+		})
+	}})
+
+	// Blocking strikes back at the attacker
+	RegisterHook(spikedShield, HookFuncs{Block: func(ctx *HitContext) {
+		if ctx.IsAttacker() {
+			return
+		}
+		ctx.Reflect(spikedShieldDmg)
+		ctx.Log(model.LogEntry{
+			Kind:   model.LogBuff,
+			Actor:  ctx.Owner.Name,
+			Target: ctx.Attacker.Name,
+			Amount: spikedShieldDmg,
+			Text:   fmt.Sprintf("%s's spiked shield bites %s for %d damage.", ctx.Owner.Name, ctx.Attacker.Name, spikedShieldDmg),
+		})
+	}})
+
+	// A chance to attack twice on the first turn of a battle
+	RegisterHook(firstStrike, HookFuncs{TurnStart: func(ctx *TurnContext) {
+		if ctx.OwnerTurn != 1 || ctx.Rng.Intn(100) >= 25 {
+			return
+		}
+		ctx.Attacks++
+		ctx.Log(buffEntry(ctx.Owner, fmt.Sprintf("%s strikes first and strikes twice!", ctx.Owner.Name)))
+	}})
+}
+
+// buffEntry creates a battle log entry for a buff or hook taking effect
+func buffEntry(owner *model.Player, text string) model.LogEntry {
+	return model.LogEntry{Kind: model.LogBuff, Actor: owner.Name, Text: text}
+}
@@ -0,0 +1,54 @@
+package model
+
+// Action is what the hero does on its turn in tactical mode
+type Action int
+
+const (
+	ActionAttack      Action = iota // A regular attack
+	ActionHeavyStrike               // A slow, telegraphed blow that hits much harder
+	ActionDefend                    // No attack, raise the guard until the next turn
+	ActionTaunt                     // A weak jab that goads the opponent into dropping its guard
+)
+
+// Actions lists every action in menu order
+var Actions = []Action{ActionAttack, ActionHeavyStrike, ActionDefend, ActionTaunt}
+
+var actionNames = [...]string{
+	ActionAttack:      "Attack",
+	ActionHeavyStrike: "Heavy Strike",
+	ActionDefend:      "Defend",
+	ActionTaunt:       "Taunt",
+}
+
+var actionKeys = [...]rune{
+	ActionAttack:      'a',
+	ActionHeavyStrike: 'h',
+	ActionDefend:      'd',
+	ActionTaunt:       't',
+}
+
+// String returns the display name of the action
+func (a Action) String() string {
+	if a < 0 || int(a) >= len(actionNames) {
+		return "Unknown"
+	}
+	return actionNames[a]
+}
+
+// Key returns the key the action is chosen with
+func (a Action) Key() rune {
+	if a < 0 || int(a) >= len(actionKeys) {
+		return 0
+	}
+	return actionKeys[a]
+}
+
+// ActionForKey returns the action chosen with the given key
+func ActionForKey(key rune) (Action, bool) {
+	for _, action := range Actions {
+		if action.Key() == key {
+			return action, true
+		}
+	}
+	return ActionAttack, false
+}
@@ -0,0 +1,15 @@
+package model
+
+// ArenaCondition is the state of the arena during a battle, it affects both combatants alike
+type ArenaCondition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Weight      int    `json:"weight"` // Relative chance the condition is rolled for a battle
+
+	CritChance  int `json:"crit_chance,omitempty"`  // Added to every crit chance
+	BlockChance int `json:"block_chance,omitempty"` // Added to every block chance
+	Evasion     int `json:"evasion,omitempty"`      // Added to every evasion
+	// Percent changes of the healing from regeneration and life steal, e.g. -50 halves it
+	RegenerationPercent int `json:"regeneration_percent,omitempty"`
+	LifeStealPercent    int `json:"life_steal_percent,omitempty"`
+}
@@ -0,0 +1,201 @@
+package model
+
+// BuffScope defines how long a buff stays active
+type BuffScope int
+
+const (
+	BuffTurns  BuffScope = iota // Lasts for a number of the owner's turns
+	BuffBattle                  // Lasts until the end of the current battle
+	BuffRun                     // Lasts for the rest of the run
+	BuffOnce                    // Consumed the first time it takes effect
+)
+
+// Modifiers are stat changes granted on top of a player's base stats
+type Modifiers struct {
+	AttackMin    int `json:"attack_min,omitempty"`
+	AttackMax    int `json:"attack_max,omitempty"`
+	Defense      int `json:"defense,omitempty"`
+	CritChance   int `json:"crit_chance,omitempty"`
+	BlockChance  int `json:"block_chance,omitempty"`
+	LifeSteal    int `json:"life_steal,omitempty"`
+	CritDamage   int `json:"crit_damage,omitempty"`
+	Regeneration int `json:"regeneration,omitempty"`
+	Speed        int `json:"speed,omitempty"`
+	Evasion      int `json:"evasion,omitempty"`
+	Revive       int `json:"revive,omitempty"`       // Percent of max health restored instead of dying
+	CritRerolls  int `json:"crit_rerolls,omitempty"` // Failed crit rolls rolled again
+
+	Resistances DamageValues `json:"resistances,omitzero"`
+	ExtraDamage DamageValues `json:"extra_damage,omitzero"`
+}
+
+// Add returns the sum of both modifiers
+func (m Modifiers) Add(other Modifiers) Modifiers {
+	return Modifiers{
+		AttackMin:    m.AttackMin + other.AttackMin,
+		AttackMax:    m.AttackMax + other.AttackMax,
+		Defense:      m.Defense + other.Defense,
+		CritChance:   m.CritChance + other.CritChance,
+		BlockChance:  m.BlockChance + other.BlockChance,
+		LifeSteal:    m.LifeSteal + other.LifeSteal,
+		CritDamage:   m.CritDamage + other.CritDamage,
+		Regeneration: m.Regeneration + other.Regeneration,
+		Speed:        m.Speed + other.Speed,
+		Evasion:      m.Evasion + other.Evasion,
+		Revive:       m.Revive + other.Revive,
+		CritRerolls:  m.CritRerolls + other.CritRerolls,
+		Resistances:  m.Resistances.Add(other.Resistances),
+		ExtraDamage:  m.ExtraDamage.Add(other.ExtraDamage),
+	}
+}
+
+// Scale returns the modifiers multiplied by n, e.g. once per stack
+func (m Modifiers) Scale(n int) Modifiers {
+	return Modifiers{
+		AttackMin:    m.AttackMin * n,
+		AttackMax:    m.AttackMax * n,
+		Defense:      m.Defense * n,
+		CritChance:   m.CritChance * n,
+		BlockChance:  m.BlockChance * n,
+		LifeSteal:    m.LifeSteal * n,
+		CritDamage:   m.CritDamage * n,
+		Regeneration: m.Regeneration * n,
+		Speed:        m.Speed * n,
+		Evasion:      m.Evasion * n,
+		Revive:       m.Revive * n,
+		CritRerolls:  m.CritRerolls * n,
+		Resistances:  m.Resistances.Scale(n),
+		ExtraDamage:  m.ExtraDamage.Scale(n),
+	}
+}
+
+// Buff is a temporary or conditional effect attached to a player
+type Buff struct {
+	Name      string
+	Source    string // What granted the buff, e.g. an upgrade name
+	Stacks    int
+	MaxStacks int // 0 means unlimited stacks
+	Scope     BuffScope
+	Turns     int       // Remaining owner turns, only used by BuffTurns
+	Mods      Modifiers // Applied once per stack
+}
+
+// AddBuff attaches a buff, stacking it onto an existing buff with the same name
+func (p *Player) AddBuff(buff Buff) {
+	if buff.Stacks < 1 {
+		buff.Stacks = 1
+	}
+
+	existing := p.GetBuff(buff.Name)
+	if existing == nil {
+		p.Buffs = append(p.Buffs, buff)
+		return
+	}
+
+	existing.Stacks += buff.Stacks
+	if existing.MaxStacks > 0 && existing.Stacks > existing.MaxStacks {
+		existing.Stacks = existing.MaxStacks
+	}
+	// Reapplying a timed buff refreshes its duration
+	existing.Turns = max(existing.Turns, buff.Turns)
+}
+
+// GetBuff returns the active buff with the given name, or nil
+func (p *Player) GetBuff(name string) *Buff {
+	for i := range p.Buffs {
+		if p.Buffs[i].Name == name {
+			return &p.Buffs[i]
+		}
+	}
+	return nil
+}
+
+// RemoveBuff removes the buff with the given name
+func (p *Player) RemoveBuff(name string) {
+	p.removeBuffs(func(b Buff) bool { return b.Name == name })
+}
+
+// ConsumeBuff removes one stack of the named buff, dropping it when no stacks remain
+func (p *Player) ConsumeBuff(name string) {
+	buff := p.GetBuff(name)
+	if buff == nil {
+		return
+	}
+	buff.Stacks--
+	if buff.Stacks <= 0 {
+		p.RemoveBuff(name)
+	}
+}
+
+// TickBuffs counts down turn based buffs after the owner acted and returns the expired ones
+func (p *Player) TickBuffs() []Buff {
+	for i := range p.Buffs {
+		if p.Buffs[i].Scope == BuffTurns {
+			p.Buffs[i].Turns--
+		}
+	}
+	return p.removeBuffs(func(b Buff) bool {
+		return b.Scope == BuffTurns && b.Turns <= 0
+	})
+}
+
+// ConsumeAttackBuffs removes one-time stat buffs after the owner attacked and returns them,
+// one-time revives are kept until they save the owner
+func (p *Player) ConsumeAttackBuffs() []Buff {
+	return p.removeBuffs(func(b Buff) bool {
+		return b.Scope == BuffOnce && b.Mods.Revive == 0
+	})
+}
+
+// EndBattleBuffs removes every buff that does not outlast the battle and returns them
+func (p *Player) EndBattleBuffs() []Buff {
+	return p.removeBuffs(func(b Buff) bool {
+		return b.Scope == BuffTurns || b.Scope == BuffBattle
+	})
+}
+
+// removeBuffs drops all buffs matching expired and returns them
+func (p *Player) removeBuffs(expired func(Buff) bool) []Buff {
+	var removed []Buff
+	kept := p.Buffs[:0]
+	for _, buff := range p.Buffs {
+		if expired(buff) {
+			removed = append(removed, buff)
+		} else {
+			kept = append(kept, buff)
+		}
+	}
+	p.Buffs = kept
+	return removed
+}
+
+// Modifiers returns the combined modifiers of all active buffs and worn equipment
+func (p *Player) Modifiers() Modifiers {
+	total := Modifiers{}
+	for _, buff := range p.Buffs {
+		total = total.Add(buff.Mods.Scale(buff.Stacks))
+	}
+	for _, item := range p.Equipment {
+		total = total.Add(item.Mods)
+	}
+	return total
+}
+
+// Effective returns a copy of the player with all modifiers applied to its stats
+func (p *Player) Effective() Player {
+	mods := p.Modifiers()
+	effective := *p
+	effective.AttackMin += mods.AttackMin
+	effective.AttackMax += mods.AttackMax
+	effective.Defense += mods.Defense
+	effective.CritChance += mods.CritChance
+	effective.BlockChance += mods.BlockChance
+	effective.LifeSteal += mods.LifeSteal
+	effective.CritDamage += mods.CritDamage
+	effective.Regeneration += mods.Regeneration
+	effective.Speed += mods.Speed
+	effective.Evasion += mods.Evasion
+	effective.Resistances = effective.Resistances.Add(mods.Resistances)
+	effective.ExtraDamage = effective.ExtraDamage.Add(mods.ExtraDamage)
+	return effective
+}
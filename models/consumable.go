@@ -0,0 +1,49 @@
+package model
+
+// Consumable is a one-use item the hero carries into battle
+type Consumable string
+
+const (
+	HealingDraught Consumable = "Healing Draught"
+	Whetstone      Consumable = "Whetstone"
+	SmokeBomb      Consumable = "Smoke Bomb"
+)
+
+// Consumables lists every consumable, the hotkey of each is its position starting at 1
+var Consumables = []Consumable{HealingDraught, Whetstone, SmokeBomb}
+
+// Key returns the hotkey the consumable is used with during battle
+func (c Consumable) Key() rune {
+	for i, consumable := range Consumables {
+		if consumable == c {
+			return rune('1' + i)
+		}
+	}
+	return 0
+}
+
+// ConsumableForKey returns the consumable used with the given hotkey
+func ConsumableForKey(key rune) (Consumable, bool) {
+	i := int(key - '1')
+	if i < 0 || i >= len(Consumables) {
+		return "", false
+	}
+	return Consumables[i], true
+}
+
+// AddConsumable puts count consumables into the player's inventory
+func (p *Player) AddConsumable(c Consumable, count int) {
+	if p.Inventory == nil {
+		p.Inventory = map[Consumable]int{}
+	}
+	p.Inventory[c] += count
+}
+
+// TakeConsumable removes one consumable from the inventory and reports whether there was one
+func (p *Player) TakeConsumable(c Consumable) bool {
+	if p.Inventory[c] < 1 {
+		return false
+	}
+	p.Inventory[c]--
+	return true
+}
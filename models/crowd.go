@@ -0,0 +1,13 @@
+package model
+
+// MaxFavor is the most favor the crowd can hold for the hero
+const MaxFavor = 100
+
+// Demand is what the audience wants to see in the current battle
+type Demand struct {
+	Name     string // Identifies the demand, its rules are kept by the game
+	Text     string // What the crowd asks for, e.g. "Win with a critical hit"
+	Progress int    // How far the hero got, e.g. critical hits landed so far
+	Goal     int    // Progress needed to meet the demand, 0 if it only needs to not fail
+	Failed   bool   // The demand can no longer be met in this battle
+}
@@ -0,0 +1,79 @@
+package model
+
+import "cmp"
+
+// DamageType is the kind of harm an attack deals, resistances are kept per type
+type DamageType string
+
+const (
+	DamageSlashing DamageType = "slashing"
+	DamagePiercing DamageType = "piercing"
+	DamageBlunt    DamageType = "blunt"
+	DamageBleed    DamageType = "bleed"
+	DamageFire     DamageType = "fire"
+	DamagePoison   DamageType = "poison"
+	DamageArcane   DamageType = "arcane"
+)
+
+// DamageTypes lists every damage type in display order
+var DamageTypes = []DamageType{
+	DamageSlashing, DamagePiercing, DamageBlunt, DamageBleed, DamageFire, DamagePoison, DamageArcane,
+}
+
+// DamageValues holds one number per damage type, e.g. resistances in percent
+type DamageValues struct {
+	Slashing int `json:"slashing,omitempty"`
+	Piercing int `json:"piercing,omitempty"`
+	Blunt    int `json:"blunt,omitempty"`
+	Bleed    int `json:"bleed,omitempty"`
+	Fire     int `json:"fire,omitempty"`
+	Poison   int `json:"poison,omitempty"`
+	Arcane   int `json:"arcane,omitempty"`
+}
+
+// Of returns the value kept for the damage type, unknown types get a throwaway value
+func (v *DamageValues) Of(t DamageType) *int {
+	switch t {
+	case DamageSlashing:
+		return &v.Slashing
+	case DamagePiercing:
+		return &v.Piercing
+	case DamageBlunt:
+		return &v.Blunt
+	case DamageBleed:
+		return &v.Bleed
+	case DamageFire:
+		return &v.Fire
+	case DamagePoison:
+		return &v.Poison
+	case DamageArcane:
+		return &v.Arcane
+	}
+	return new(int)
+}
+
+// Get returns the value kept for the damage type
+func (v DamageValues) Get(t DamageType) int {
+	return *v.Of(t)
+}
+
+// Add returns the sum of both values, type by type
+func (v DamageValues) Add(other DamageValues) DamageValues {
+	for _, t := range DamageTypes {
+		*v.Of(t) += other.Get(t)
+	}
+	return v
+}
+
+// Scale returns the values multiplied by n
+func (v DamageValues) Scale(n int) DamageValues {
+	for _, t := range DamageTypes {
+		*v.Of(t) *= n
+	}
+	return v
+}
+
+// AttackType returns the damage type of the player's weapon attacks, slashing by default
+func (p *Player) AttackType() DamageType {
+	return cmp.Or(p.DamageType, DamageSlashing)
+}
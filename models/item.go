@@ -0,0 +1,44 @@
+package model
+
+// Slot is the place on the body an item is worn in
+type Slot string
+
+const (
+	SlotWeapon    Slot = "weapon"
+	SlotArmor     Slot = "armor"
+	SlotShield    Slot = "shield"
+	SlotAccessory Slot = "accessory"
+)
+
+// Slots lists every equipment slot in display order
+var Slots = []Slot{SlotWeapon, SlotArmor, SlotShield, SlotAccessory}
+
+// Item is a piece of equipment whose stats only apply while it is worn
+type Item struct {
+	Name        string    `json:"name"`
+	Slot        Slot      `json:"slot"`
+	Description string    `json:"description"`
+	Mods        Modifiers `json:"mods"`
+	Hooks       []string  `json:"hooks,omitempty"` // Combat hooks active while the item is worn
+}
+
+// Equip puts an item into its slot and returns the item it replaced, if any
+func (p *Player) Equip(item Item) (Item, bool) {
+	previous, replaced := p.Equipment[item.Slot]
+	if p.Equipment == nil {
+		p.Equipment = map[Slot]Item{}
+	}
+	p.Equipment[item.Slot] = item
+	return previous, replaced
+}
+
+// EquippedItems returns the worn items in slot order
+func (p *Player) EquippedItems() []Item {
+	items := []Item{}
+	for _, slot := range Slots {
+		if item, ok := p.Equipment[slot]; ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
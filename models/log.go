@@ -0,0 +1,78 @@
+package model
+
+import "fmt"
+
+// LogKind categorizes a battle log entry so it can be styled and analyzed
+type LogKind int
+
+const (
+	LogNarration LogKind = iota // Plain story text, headers and blank lines
+	LogHit                      // A regular attack
+	LogCrit                     // An attack that landed a critical hit
+	LogBlock                    // An attack that was partially blocked
+	LogRegen                    // Health regained through regeneration
+	LogVictory                  // The hero won a battle or the whole run
+	LogDefeat                   // The hero has fallen
+	LogUpgrade                  // An upgrade was chosen
+	LogBuff                     // A buff was gained, triggered or expired
+	LogPhase                    // A boss entered a new phase of the fight
+	LogDodge                    // An attack that was dodged entirely
+	LogStatus                   // A status effect was inflicted, ticked or wore off
+)
+
+var logKindNames = [...]string{
+	LogNarration: "narration",
+	LogHit:       "hit",
+	LogCrit:      "crit",
+	LogBlock:     "block",
+	LogRegen:     "regen",
+	LogVictory:   "victory",
+	LogDefeat:    "defeat",
+	LogUpgrade:   "upgrade",
+	LogBuff:      "buff",
+	LogPhase:     "phase",
+	LogDodge:     "dodge",
+	LogStatus:    "status",
+}
+
+// String returns the lowercase name of the kind
+func (k LogKind) String() string {
+	if k < 0 || int(k) >= len(logKindNames) {
+		return "unknown"
+	}
+	return logKindNames[k]
+}
+
+// MarshalText encodes the kind by name so exported logs stay readable
+func (k LogKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes a kind previously written by MarshalText, unknown names are an
+// error so a renamed kind cannot silently turn saved logs into narration
+func (k *LogKind) UnmarshalText(text []byte) error {
+	for i, name := range logKindNames {
+		if name == string(text) {
+			*k = LogKind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown log kind %q", text)
+}
+
+// LogEntry is a single structured line of the battle log
+type LogEntry struct {
+	Kind   LogKind `json:"kind"`
+	Turn   int     `json:"turn"`             // Attack index within the battle, 0 outside of turns
+	Actor  string  `json:"actor,omitempty"`  // Who acted, e.g. the attacker
+	Target string  `json:"target,omitempty"` // Who was acted upon, e.g. the defender
+	Amount int     `json:"amount,omitempty"` // Damage dealt or health regained
+	Text   string  `json:"text"`             // Human readable message
+
+	Types []DamageType `json:"types,omitempty"` // Damage types of a hit
+}
+
+// Narration creates a plain log entry from text
+func Narration(text string) LogEntry {
+	return LogEntry{Kind: LogNarration, Text: text}
+}
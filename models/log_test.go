@@ -0,0 +1,66 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLogEntryJSONRoundTrip(t *testing.T) {
+	entry := LogEntry{
+		Kind:   LogCrit,
+		Turn:   3,
+		Actor:  "Hero",
+		Target: "Goblin",
+		Amount: 24,
+		Text:   "Hero hits Goblin for 24 damage!",
+		Types:  []DamageType{DamageSlashing, DamageFire},
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"kind":"crit"`) {
+		t.Errorf("kind should be encoded by name, got %s", data)
+	}
+
+	var decoded LogEntry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.Kind != entry.Kind || decoded.Turn != entry.Turn || decoded.Actor != entry.Actor ||
+		decoded.Target != entry.Target || decoded.Amount != entry.Amount || decoded.Text != entry.Text ||
+		len(decoded.Types) != len(entry.Types) {
+		t.Errorf("round trip changed the entry: got %+v, want %+v", decoded, entry)
+	}
+}
+
+func TestLogKindText(t *testing.T) {
+	for kind := LogNarration; int(kind) < len(logKindNames); kind++ {
+		text, err := kind.MarshalText()
+		if err != nil {
+			t.Fatalf("%d: marshal: %v", kind, err)
+		}
+		var decoded LogKind
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("%s: unmarshal: %v", text, err)
+		}
+		if decoded != kind {
+			t.Errorf("%s decoded as %s", text, decoded)
+		}
+	}
+
+	if got := LogKind(-1).String(); got != "unknown" {
+		t.Errorf("invalid kind is named %q, want unknown", got)
+	}
+
+	var decoded LogKind
+	if err := decoded.UnmarshalText([]byte("no such kind")); err == nil {
+		t.Errorf("unknown names should be an error, decoded as %s", decoded)
+	}
+	var entry LogEntry
+	if err := json.Unmarshal([]byte(`{"kind":"no such kind","text":"?"}`), &entry); err == nil {
+		t.Error("an entry with an unknown kind should not decode")
+	}
+}
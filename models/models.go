@@ -1,9 +1,17 @@
 package model
 
+import (
+	"math/rand"
+	"slices"
+)
+
 // Player represents a gladiator with stats and abilities
 // TODO: make fields private and creates getters/setters if needed
 type Player struct {
 	Name         string
+	Class        string    // Hero class the run was started as, empty for enemies
+	Patron       string    `json:",omitempty"` // God the hero pledged the run to
+	Blessing     *Blessing `json:",omitempty"` // Active power of the patron, nil without one
 	Health       int
 	MaxHealth    int
 	AttackMin    int
@@ -17,19 +25,45 @@ type Player struct {
 	CritDamage   int
 	Regeneration int
 	LifeOnKill   int
+	Speed        int          // How quickly the player acts on the initiative timeline
+	Evasion      int          // Percent chance to dodge an attack entirely
+	DamageType   DamageType   `json:",omitempty"` // Type of weapon damage, see AttackType
+	Resistances  DamageValues // Percent of damage of each type shrugged off, negative for weaknesses
+	ExtraDamage  DamageValues // Flat typed damage added to every hit
 	Description  string
+	Buffs        []Buff
+	Hooks        []string       // Names of registered combat hooks, see game.RegisterHook
+	Counters     map[string]int // Per-battle counters, cleared when the battle ends
+	// How often each upgrade was taken during the run
+	UpgradeLevels map[string]int
+	Equipment     map[Slot]Item
+	Inventory     map[Consumable]int // Consumables carried, by how many are left
+	Affixes       []string           `json:",omitempty"` // Traits of an elite or endless enemy
+	Elite         bool               `json:",omitempty"` // Regular enemy rolled as an elite, see IsElite
+	Phases        []Phase            `json:",omitempty"` // Boss phases not yet entered, in order
+	Phase         string             `json:",omitempty"` // Name of the boss phase the player is in
+	Statuses      []Status           `json:",omitempty"` // Status effects such as bleeding, cleared when the battle ends
+}
+
+// IsElite reports whether the player is a regular enemy rolled as an elite. Endless
+// enemies carry affixes as well, but they are not elites and give no extra reward.
+func (p *Player) IsElite() bool {
+	return p.Elite
 }
 
 // BattleResult contains the outcome of an attack
 type BattleResult struct {
-	Attacker     *Player
-	Defender     *Player
-	Damage       int
-	IsCritical   bool
-	IsBlocked    bool
-	IsGameOver   bool
-	WinnerName   string
-	Regeneration int
+	Attacker   *Player
+	Defender   *Player
+	Damage     int
+	IsCritical bool
+	IsBlocked  bool
+	IsDodged   bool         // The attack missed and dealt no damage at all
+	Types      []DamageType // Damage types the hit dealt, the weapon's type first
+	IsGameOver bool
+	WinnerName string
+	Events     []LogEntry // Entries written by hooks while resolving the attack
+	Action     Action     // What the attacker did
 }
 
 // GameState tracks the overall game progression
@@ -38,15 +72,32 @@ type GameState struct {
 	UpgradeMode     bool
 	Upgrades        []Upgrade
 	SelectedUpgrade int
-	BattleLog       []string
+	BattleLog       []LogEntry
 	GameOver        bool
+	GoldEarned      int            // Gold the run has earned so far
+	Endless         bool           // The run continues past the final boss
+	Tactical        bool           // The player picks the hero's action every turn
+	Arena           ArenaCondition // Condition the current battle is fought under
+	Favor           int            // Crowd favor earned by meeting demands, up to MaxFavor
+	Demand          *Demand        // What the audience wants to see in the current battle
+	AwaitingAction  bool           `json:"-"` // A tactical battle waits for the player's choice
+	Seed            int64          // Seed the run's random generator was created with
+	Rng             *rand.Rand     `json:"-"` // Source of every random roll during the run
+	RngSource       *RandSource    `json:"-"` // Tracks the position of Rng for save files
 }
 
 // Upgrade represents a possible improvement for the hero
 type Upgrade struct {
 	Name        string
 	Description string
-	Effect      func(*Player)
+	Effect      func(*Player) `json:"-"` // Rebound by name when a run is loaded
+	// Item offered as loot, taking loot does not use up the upgrade choice
+	Item string `json:",omitempty"`
+}
+
+// IsLoot reports whether the choice equips an item instead of upgrading the hero
+func (u Upgrade) IsLoot() bool {
+	return u.Item != ""
 }
 
 const (
@@ -57,10 +108,52 @@ const (
 	// BattleLogs
 	CriticalHit = "CRITICAL HIT"
 	Blocked     = "BLOCKED"
-	Victorious   = "VICTORIOUS"
+	Victorious  = "VICTORIOUS"
 )
 
-// AddToBattleLog adds a message to the battle log
-func (gs *GameState) AddToBattleLog(message string) {
-	gs.BattleLog = append(gs.BattleLog, message)
+// AddHook attaches a registered combat hook by name, once
+func (p *Player) AddHook(name string) {
+	if !slices.Contains(p.Hooks, name) {
+		p.Hooks = append(p.Hooks, name)
+	}
+}
+
+// Counter returns the current value of a per-battle counter
+func (p *Player) Counter(name string) int {
+	return p.Counters[name]
+}
+
+// IncrementCounter increases a per-battle counter by 1 and returns the new value
+func (p *Player) IncrementCounter(name string) int {
+	if p.Counters == nil {
+		p.Counters = map[string]int{}
+	}
+	p.Counters[name]++
+	return p.Counters[name]
+}
+
+// RaiseCounter sets a per-battle counter to value if that is higher, e.g. to remember a peak
+func (p *Player) RaiseCounter(name string, value int) {
+	if value <= p.Counter(name) {
+		return
+	}
+	if p.Counters == nil {
+		p.Counters = map[string]int{}
+	}
+	p.Counters[name] = value
+}
+
+// ResetCounters clears all per-battle counters
+func (p *Player) ResetCounters() {
+	p.Counters = nil
+}
+
+// AddToBattleLog adds an entry to the battle log
+func (gs *GameState) AddToBattleLog(entry LogEntry) {
+	gs.BattleLog = append(gs.BattleLog, entry)
+}
+
+// Narrate adds a plain narration line to the battle log
+func (gs *GameState) Narrate(message string) {
+	gs.AddToBattleLog(Narration(message))
 }
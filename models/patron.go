@@ -0,0 +1,13 @@
+package model
+
+// Blessing is the active power a patron god grants, charged by fighting
+type Blessing struct {
+	Name   string `json:"name"`
+	Charge int    `json:"charge"` // Hero turns fought since the battle started or the blessing was last used
+	Needed int    `json:"needed"` // Charge the blessing needs before it can be invoked
+}
+
+// Ready reports whether the blessing is charged
+func (b *Blessing) Ready() bool {
+	return b.Charge >= b.Needed
+}
@@ -0,0 +1,11 @@
+package model
+
+// Phase is a stage of a boss fight, entered once the boss drops below a health threshold
+type Phase struct {
+	Name     string    `json:"name"`
+	Below    int       `json:"below"`           // Percent of max health under which the phase starts
+	Announce string    `json:"announce"`        // Battle log line when the phase starts
+	Heal     int       `json:"heal,omitempty"`  // Percent of max health restored when the phase starts
+	Mods     Modifiers `json:"mods,omitempty"`  // Applied for the rest of the battle
+	Hooks    []string  `json:"hooks,omitempty"` // Combat hooks gained for the rest of the battle
+}
@@ -0,0 +1,52 @@
+package model
+
+import "math/rand"
+
+// RandSource is a seeded random source that counts its draws, so the exact
+// position of a run in its random stream can be saved and restored
+type RandSource struct {
+	seed  int64
+	draws uint64
+	src   rand.Source64
+}
+
+// NewRandSource creates a source at the start of the stream for seed
+func NewRandSource(seed int64) *RandSource {
+	return &RandSource{
+		seed: seed,
+		src:  rand.NewSource(seed).(rand.Source64),
+	}
+}
+
+// RestoreRandSource recreates a source that already made draws draws
+func RestoreRandSource(seed int64, draws uint64) *RandSource {
+	s := NewRandSource(seed)
+	for s.draws < draws {
+		s.Uint64()
+	}
+	return s
+}
+
+// Int63 implements rand.Source
+func (s *RandSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+// Uint64 implements rand.Source64
+func (s *RandSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+// Seed implements rand.Source and restarts the stream
+func (s *RandSource) Seed(seed int64) {
+	s.seed = seed
+	s.draws = 0
+	s.src.Seed(seed)
+}
+
+// Draws returns how many values were drawn since the source was seeded
+func (s *RandSource) Draws() uint64 {
+	return s.draws
+}
@@ -0,0 +1,83 @@
+package model
+
+// StatusKind names a status effect, such as bleeding or being stunned
+type StatusKind string
+
+const (
+	StatusBleed    StatusKind = "Bleeding"
+	StatusPoison   StatusKind = "Poisoned"
+	StatusBurn     StatusKind = "Burning"
+	StatusStun     StatusKind = "Stunned"
+	StatusGrievous StatusKind = "Grievously Wounded"
+)
+
+// StatusKinds lists every status effect in display order
+var StatusKinds = []StatusKind{StatusBleed, StatusPoison, StatusBurn, StatusStun, StatusGrievous}
+
+var statusIcons = map[StatusKind]string{
+	StatusBleed:    "🩸",
+	StatusPoison:   "🧪",
+	StatusBurn:     "🔥",
+	StatusStun:     "💫",
+	StatusGrievous: "💔",
+}
+
+// Icon returns the symbol the status is shown with
+func (k StatusKind) Icon() string {
+	return statusIcons[k]
+}
+
+// Status is an effect that ticks at the start of each of its owner's turns until it runs out
+type Status struct {
+	Kind   StatusKind `json:"kind"`
+	Stacks int        `json:"stacks"`
+	Turns  int        `json:"turns"` // Owner turns left before it wears off
+}
+
+// AddStatus inflicts a status, stacking it onto an active one of the same kind up to
+// maxStacks and refreshing its duration
+func (p *Player) AddStatus(kind StatusKind, stacks, turns, maxStacks int) {
+	existing := p.GetStatus(kind)
+	if existing == nil {
+		p.Statuses = append(p.Statuses, Status{Kind: kind, Stacks: min(stacks, maxStacks), Turns: turns})
+		return
+	}
+	existing.Stacks = min(existing.Stacks+stacks, maxStacks)
+	existing.Turns = max(existing.Turns, turns)
+}
+
+// GetStatus returns the active status of the given kind, or nil
+func (p *Player) GetStatus(kind StatusKind) *Status {
+	for i := range p.Statuses {
+		if p.Statuses[i].Kind == kind {
+			return &p.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// HasStatus reports whether a status of the given kind is active
+func (p *Player) HasStatus(kind StatusKind) bool {
+	return p.GetStatus(kind) != nil
+}
+
+// TickStatuses counts down every status after it took effect and returns the expired ones
+func (p *Player) TickStatuses() []Status {
+	var expired []Status
+	kept := p.Statuses[:0]
+	for _, status := range p.Statuses {
+		status.Turns--
+		if status.Turns <= 0 {
+			expired = append(expired, status)
+		} else {
+			kept = append(kept, status)
+		}
+	}
+	p.Statuses = kept
+	return expired
+}
+
+// ClearStatuses removes every status, e.g. when the battle ends
+func (p *Player) ClearStatuses() {
+	p.Statuses = nil
+}
@@ -20,12 +20,17 @@ var (
 	selectedStyle = defaultStyle.Background(tcell.ColorGainsboro).Foreground(tcell.ColorWhite)
 	criticalStyle = defaultStyle.Foreground(tcell.ColorYellow)
 	blockStyle    = defaultStyle.Foreground(tcell.ColorTeal)
+	buffStyle     = defaultStyle.Foreground(tcell.ColorFuchsia)
+	phaseStyle    = defaultStyle.Bold(true).Foreground(tcell.ColorOrangeRed)
+	dodgeStyle    = defaultStyle.Italic(true).Foreground(tcell.ColorSilver)
+	statusStyle   = defaultStyle.Foreground(tcell.ColorMediumOrchid)
 )
 
 const (
 	// UI
 	healthBarWidth = 20
-	logStartY      = 8
+	favorBarWidth  = 10
+	logStartY      = 9
 	maxLogEntries  = 20
 	heroXIndex     = 2
 	enemyXIndex    = 52
@@ -38,8 +43,12 @@ const (
 	prefixUnselected = "   "
 	prefixSelected   = ">> "
 	upgradeHelper    = "Use UP/DOWN arrows to select, ENTER to confirm"
-	gameOverHelper   = "Game Over! Press 'q' to exit or 'r' to start a new run."
-	quitHelper       = "Press 'q' to quit."
+	gameOverHelper   = "Game Over! Press 'q' to exit or 'r' to visit the shop and start a new run."
+	victoryHelper    = "Victory! Press 'e' for endless mode, 'r' to visit the shop and start a new run or 'q' to exit."
+	endlessText      = " - ENDLESS MODE"
+	actionText       = "YOUR MOVE:"
+	quitHelper       = "Press 1-3 to use a consumable, 'q' to quit."
+	blessingHelper   = "Press 1-3 to use a consumable, 'b' to invoke %s %s, 'q' to quit."
 )
 
 // drawHealthBar creates a visual health bar
@@ -67,7 +76,17 @@ func DrawUI(screen tcell.Screen, hero *model.Player, enemy *model.Player, gameSt
 	defer screen.Show()
 
 	// Draw title and stats
-	printText(screen, 2, 1, titleText, titleStyle)
+	title := titleText
+	if gameState.Endless {
+		title += endlessText
+	}
+	printText(screen, 2, 1, title, titleStyle)
+	// The arena condition of the battle is shown next to the title
+	if gameState.Arena.Name != "" {
+		arena := fmt.Sprintf("Arena: %s - %s", gameState.Arena.Name, gameState.Arena.Description)
+		printText(screen, 4+runewidth.StringWidth(title), 1, arena, infoStyle)
+	}
+	printText(screen, 2, 2, generateCrowdString(gameState), infoStyle)
 
 	// Draw players (hero & enemy) stats with health bars
 	drawPlayer(screen, hero)
@@ -76,27 +95,15 @@ func DrawUI(screen tcell.Screen, hero *model.Player, enemy *model.Player, gameSt
 	// Draw battle log with scrolling
 	printText(screen, 2, 11, battleLogText, titleStyle)
 
-	// TODO: refactor BattleLog to be its own type that includes the string + the status (crit, block, victory...)
-	// so we can avoid strings.Contains for styling
 	displayLog := gameState.BattleLog
 	// Pop the top logs if the length of the battle is too long to display everything
 	if len(gameState.BattleLog) > maxLogEntries {
 		displayLog = gameState.BattleLog[len(gameState.BattleLog)-maxLogEntries:]
 	}
 
-	// Style the log if special (crit, block, victory)
-	for i, line := range displayLog {
-		style := defaultStyle
-		switch {
-		case strings.Contains(line, model.CriticalHit):
-			printText(screen, 2, logStartY+i, line, criticalStyle)
-		case strings.Contains(line, model.Blocked):
-			printText(screen, 2, logStartY+i, line, blockStyle)
-		case strings.Contains(line, model.Victorious):
-			printText(screen, 2, logStartY+i, line, titleStyle)
-		default:
-			printText(screen, 2, logStartY+i, line, style)
-		}
+	// Style the log by the kind of entry (crit, block, victory...)
+	for i, entry := range displayLog {
+		printText(screen, 2, logStartY+i, entry.Text, logStyle(entry.Kind))
 	}
 
 	// Show controls or upgrade options
@@ -116,28 +123,100 @@ func DrawUI(screen tcell.Screen, hero *model.Player, enemy *model.Player, gameSt
 			printText(screen, 2, controlsY+i+1, fmt.Sprintf("%s%d. %s - %s", prefix, i+1, upgrade.Name, upgrade.Description), style)
 		}
 		printText(screen, 2, controlsY+len(gameState.Upgrades)+2, upgradeHelper, infoStyle)
+	case gameState.GameOver && hero.Health > 0 && !gameState.Endless:
+		printText(screen, 2, controlsY, victoryHelper, infoStyle)
 	case gameState.GameOver:
 		printText(screen, 2, controlsY, gameOverHelper, infoStyle)
+	case gameState.AwaitingAction:
+		printText(screen, 2, controlsY, actionText, titleStyle)
+		printText(screen, 2, controlsY+1, generateActionsString(), selectedStyle)
+		printText(screen, 2, controlsY+3, generateHelperString(hero), infoStyle)
 	default:
-		printText(screen, 2, controlsY, quitHelper, infoStyle)
+		printText(screen, 2, controlsY, generateHelperString(hero), infoStyle)
 	}
 }
 
-func generateBuffsString(player *model.Player) string {
-	// TODO: is the check necessary? Maybe we want to print buffs for enemies too?
-	if !player.IsHero {
-		return ""
+// logStyle returns the style a battle log entry of the given kind is drawn with
+func logStyle(kind model.LogKind) tcell.Style {
+	switch kind {
+	case model.LogCrit:
+		return criticalStyle
+	case model.LogBlock:
+		return blockStyle
+	case model.LogRegen:
+		return heroStyle
+	case model.LogVictory:
+		return titleStyle
+	case model.LogDefeat:
+		return enemyStyle
+	case model.LogUpgrade:
+		return infoStyle
+	case model.LogBuff:
+		return buffStyle
+	case model.LogPhase:
+		return phaseStyle
+	case model.LogDodge:
+		return dodgeStyle
+	case model.LogStatus:
+		return statusStyle
+	default:
+		return defaultStyle
 	}
+}
 
-	// TODO: create an array of buffs that were selected to print them here
+func generateBuffsString(player *model.Player) string {
 	buffs := ""
-	if player.Regeneration > 0 {
+	if player.Effective().Regeneration > 0 {
 		buffs += " 🌿"
 	}
 
+	for _, buff := range player.Buffs {
+		buffs += " " + buff.Name
+		if buff.Stacks > 1 {
+			buffs += fmt.Sprintf(" x%d", buff.Stacks)
+		}
+		if buff.Scope == model.BuffTurns {
+			buffs += fmt.Sprintf(" (%d)", buff.Turns)
+		}
+	}
+
 	return buffs
 }
 
+// generateCrowdString shows the crowd favor meter and how the demand of the battle is going,
+// e.g. "Crowd: [█████░░░░░] 50/100 | Demand: Land 3 critical hits (1/3)"
+func generateCrowdString(gameState *model.GameState) string {
+	text := fmt.Sprintf("Crowd: %s %d/%d", drawHealthBar(gameState.Favor, model.MaxFavor, favorBarWidth), gameState.Favor, model.MaxFavor)
+	demand := gameState.Demand
+	if demand == nil {
+		return text
+	}
+
+	text += " | Demand: " + demand.Text
+	switch {
+	case demand.Failed:
+		text += " ✘"
+	case demand.Goal > 0 && demand.Progress >= demand.Goal:
+		text += " ✔"
+	case demand.Goal > 0:
+		text += fmt.Sprintf(" (%d/%d)", demand.Progress, demand.Goal)
+	}
+	return text
+}
+
+// generateStatusString shows the active statuses by icon with their stacks and remaining turns
+func generateStatusString(player *model.Player) string {
+	statuses := ""
+	for _, status := range player.Statuses {
+		statuses += " " + status.Kind.Icon()
+		if status.Stacks > 1 {
+			statuses += fmt.Sprintf("x%d", status.Stacks)
+		}
+		statuses += fmt.Sprintf(" (%d)", status.Turns)
+	}
+	return statuses
+}
+
 // Helper function to draw text with proper handling of wide characters
 // TODO: refactor to pass only screen + an object that contains the other characters
 func printText(screen tcell.Screen, x, y int, text string, style tcell.Style) {
@@ -160,17 +239,107 @@ func drawPlayer(screen tcell.Screen, player *model.Player) {
 		style = enemyStyle
 	}
 
+	// Show stats including everything buffs add on top
+	stats := player.Effective()
+
 	healthBar := drawHealthBar(player.Health, player.MaxHealth, healthBarWidth)
-	printText(screen, xIndex, startYIndex, fmt.Sprintf("%s %s", player.Name, generateBuffsString(player)), style)
+	name := player.Name
+	if player.Class != "" {
+		name += " the " + player.Class
+	}
+	// A boss changing phase looks the part
+	if player.Phase != "" {
+		name += " [" + strings.ToUpper(player.Phase) + "]"
+		style = phaseStyle
+	}
+	printText(screen, xIndex, startYIndex, fmt.Sprintf("%s %s%s", name, generateBuffsString(player), generateStatusString(player)), style)
 	startYIndex++
 	printText(screen, xIndex, startYIndex, fmt.Sprintf("%s %s", formatLifeCount(player.Health, player.MaxHealth), healthBar), style)
 	startYIndex++
-	switch {
-	case player.IsHero:
-		printText(screen, xIndex, startYIndex, fmt.Sprintf("ATK: %d-%d | DEF: %d | Wins: %d", player.AttackMin, player.AttackMax, player.Defense, player.Wins), style)
-	default:
-		printText(screen, xIndex, startYIndex, fmt.Sprintf("ATK: %d-%d | DEF: %d", player.AttackMin, player.AttackMax, player.Defense), style)
+	statLine := fmt.Sprintf("ATK: %d-%d | DEF: %d | SPD: %d", stats.AttackMin, stats.AttackMax, stats.Defense, stats.Speed)
+	// Evasion is rare, so it only takes up room when there is some
+	if stats.Evasion > 0 {
+		statLine += fmt.Sprintf(" | EVA: %d%%", stats.Evasion)
+	}
+	if player.IsHero {
+		statLine += fmt.Sprintf(" | Wins: %d", player.Wins)
+	}
+	printText(screen, xIndex, startYIndex, statLine, style)
+	startYIndex++
+	// Both sides show their damage types, the hero gains some from upgrades
+	printText(screen, xIndex, startYIndex, generateDamageString(stats), infoStyle)
+	if player.IsHero {
+		startYIndex++
+		printText(screen, xIndex, startYIndex, generateGearString(player), infoStyle)
+		startYIndex++
+		printText(screen, xIndex, startYIndex, generateInventoryString(player), infoStyle)
+	}
+}
+
+// generateDamageString shows the damage types a player deals and its resistances, e.g.
+// "DMG: blunt +8 fire | RES: blunt 20%, fire -10%"
+func generateDamageString(stats model.Player) string {
+	dmg := []string{string(stats.AttackType())}
+	res := []string{}
+	for _, damageType := range model.DamageTypes {
+		if extra := stats.ExtraDamage.Get(damageType); extra > 0 {
+			dmg = append(dmg, fmt.Sprintf("+%d %s", extra, damageType))
+		}
+		if resistance := stats.Resistances.Get(damageType); resistance != 0 {
+			res = append(res, fmt.Sprintf("%s %d%%", damageType, resistance))
+		}
+	}
+
+	text := "DMG: " + strings.Join(dmg, " ")
+	if len(res) > 0 {
+		text += " | RES: " + strings.Join(res, ", ")
+	}
+	return text
+}
+
+// generateGearString lists the item worn in every slot, empty slots are shown as "-"
+func generateGearString(player *model.Player) string {
+	gear := make([]string, 0, len(model.Slots))
+	for _, slot := range model.Slots {
+		name := "-"
+		if item, ok := player.Equipment[slot]; ok {
+			name = item.Name
+		}
+		gear = append(gear, name)
+	}
+	return "Gear: " + strings.Join(gear, " | ")
+}
+
+// generateActionsString lists the tactical actions with their keys
+func generateActionsString() string {
+	actions := make([]string, 0, len(model.Actions))
+	for _, action := range model.Actions {
+		actions = append(actions, fmt.Sprintf("[%c] %s", action.Key(), action))
+	}
+	return strings.Join(actions, "  ")
+}
+
+// generateHelperString lists the keys usable during a battle, including the hero's
+// blessing with how far it is charged
+func generateHelperString(hero *model.Player) string {
+	blessing := hero.Blessing
+	if blessing == nil {
+		return quitHelper
+	}
+	charge := fmt.Sprintf("(%d/%d)", blessing.Charge, blessing.Needed)
+	if blessing.Ready() {
+		charge = "(READY)"
+	}
+	return fmt.Sprintf(blessingHelper, blessing.Name, charge)
+}
+
+// generateInventoryString lists the consumables with their hotkeys and how many are left
+func generateInventoryString(player *model.Player) string {
+	bag := make([]string, 0, len(model.Consumables))
+	for _, c := range model.Consumables {
+		bag = append(bag, fmt.Sprintf("[%c] %s x%d", c.Key(), c, player.Inventory[c]))
 	}
+	return "Bag: " + strings.Join(bag, " | ")
 }
 
 func formatLifeCount(health, maxHealth int) string {
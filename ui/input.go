@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"math/rand"
+	"slices"
+
 	model "gladiator-sim/models"
 
 	"github.com/gdamore/tcell/v2"
@@ -9,10 +12,17 @@ import (
 // InputHandler defines the interface for handling game input
 type InputHandler interface {
 	HandleUpgrade(hero *model.Player, upgrade model.Upgrade)
-	CreateEnemy(level int) *model.Player
+	CreateEnemy(rng *rand.Rand, level int) *model.Player
 	ResetHero(hero *model.Player)
 	ResetGameState(state *model.GameState)
 	StartBattle(hero, enemy *model.Player, screen tcell.Screen, state *model.GameState, quit, done chan bool)
+	UseConsumable(c model.Consumable) // Called while the battle runs, must be safe for concurrent use
+	ShopOffers() (gold int, offers []ShopOffer)
+	Buy(index int) error
+	ContinueEndless(hero, enemy *model.Player, state *model.GameState) bool
+	ChooseAction(action model.Action) // Called while the battle waits, must be safe for concurrent use
+	ClassOptions() []MenuOption       // Hero classes unlocked so far
+	InvokeBlessing()                  // Called while the battle runs, must be safe for concurrent use
 }
 
 // StartInputHandler initializes the input handling goroutine
@@ -52,7 +62,7 @@ func HandleInput(
 		if gameState.UpgradeMode {
 			return handleUpgradeInput(ev, screen, hero, enemy, gameState, handler, quit, done)
 		} else {
-			return handleRegularInput(ev, screen, hero, gameState, handler, quit, done)
+			return handleRegularInput(ev, screen, hero, enemy, gameState, handler, quit, done)
 		}
 	case *tcell.EventResize:
 		screen.Sync()
@@ -82,26 +92,43 @@ func handleUpgradeInput(ev *tcell.EventKey,
 		DrawUI(screen, hero, enemy, gameState)
 		return false
 	case tcell.KeyEnter:
-		handler.HandleUpgrade(hero, gameState.Upgrades[gameState.SelectedUpgrade])
+		chosen := gameState.Upgrades[gameState.SelectedUpgrade]
+		handler.HandleUpgrade(hero, chosen)
+
+		// Loot is equipped on the spot, the hero still gets to pick an upgrade
+		if chosen.IsLoot() {
+			gameState.AddToBattleLog(model.LogEntry{
+				Kind:  model.LogUpgrade,
+				Actor: hero.Name,
+				Text:  "Equipped: " + chosen.Item,
+			})
+			gameState.Upgrades = slices.Delete(gameState.Upgrades, gameState.SelectedUpgrade, gameState.SelectedUpgrade+1)
+			gameState.SelectedUpgrade = min(gameState.SelectedUpgrade, len(gameState.Upgrades)-1)
+			DrawUI(screen, hero, enemy, gameState)
+			return false
+		}
 
 		// Prepare for next battle
 		gameState.CurrentEnemy++
 		gameState.UpgradeMode = false
-		newEnemy := handler.CreateEnemy(gameState.CurrentEnemy)
+		// The enemy is replaced in place, so every key handled later sees the current opponent
+		*enemy = *handler.CreateEnemy(gameState.Rng, gameState.CurrentEnemy)
 
-		gameState.AddToBattleLog(
-			"Upgrade chosen: " + gameState.Upgrades[gameState.SelectedUpgrade].Name)
-		gameState.AddToBattleLog(
-			"Preparing for battle against " + newEnemy.Name + "...")
+		gameState.AddToBattleLog(model.LogEntry{
+			Kind:  model.LogUpgrade,
+			Actor: hero.Name,
+			Text:  "Upgrade chosen: " + chosen.Name,
+		})
+		gameState.Narrate("Preparing for battle against " + enemy.Name + "...")
 
-		handler.StartBattle(hero, newEnemy, screen, gameState, quit, done)
+		handler.StartBattle(hero, enemy, screen, gameState, quit, done)
 		return false
 	}
 	return false
 }
 
 // handleRegularInput processes input during normal gameplay
-func handleRegularInput(ev *tcell.EventKey, screen tcell.Screen, hero *model.Player, gameState *model.GameState,
+func handleRegularInput(ev *tcell.EventKey, screen tcell.Screen, hero, enemy *model.Player, gameState *model.GameState,
 	handler InputHandler, quit chan bool, done chan bool) bool {
 
 	if ev.Key() == tcell.KeyRune {
@@ -112,13 +139,49 @@ func handleRegularInput(ev *tcell.EventKey, screen tcell.Screen, hero *model.Pla
 		case 'r':
 			// Only allow restart after game over
 			if gameState.GameOver {
+				// Gold earned in the run is spent before the next one starts
+				unlocked := len(handler.ClassOptions())
+				ShowShop(screen, handler)
+
+				// A class bought in the shop can be played right away, escape keeps the old one
+				if classes := handler.ClassOptions(); len(classes) > unlocked {
+					if i := showMenu(screen, "A new fighting style awaits, choose your class:", classes); i >= 0 {
+						hero.Class = classes[i].Name
+					}
+				}
 				handler.ResetHero(hero)
 				handler.ResetGameState(gameState)
 
-				newEnemy := handler.CreateEnemy(gameState.CurrentEnemy)
+				// Replaced in place like the hero, see handleUpgradeInput
+				*enemy = *handler.CreateEnemy(gameState.Rng, gameState.CurrentEnemy)
 
-				gameState.AddToBattleLog("Starting a new adventure...")
-				handler.StartBattle(hero, newEnemy, screen, gameState, quit, done)
+				gameState.Narrate("Starting a new adventure...")
+				handler.StartBattle(hero, enemy, screen, gameState, quit, done)
+			}
+			return false
+		case 'e':
+			// Only a run that beat the final boss can go on
+			if handler.ContinueEndless(hero, enemy, gameState) {
+				DrawUI(screen, hero, enemy, gameState)
+			}
+			return false
+		case 'b':
+			// The patron answers on the hero's turn, or right away while a tactical battle waits
+			if !gameState.GameOver && hero.Blessing != nil {
+				handler.InvokeBlessing()
+			}
+			return false
+		default:
+			// In tactical mode the battle waits for the hero's action. Choices made while it
+			// does not wait are dropped by the battle, so the input never reads its state.
+			if action, ok := model.ActionForKey(ev.Rune()); ok {
+				handler.ChooseAction(action)
+				return false
+			}
+			// Hotkeys use consumables in the running battle. The inventory belongs to the battle
+			// goroutine, which skips consumables the hero has run out of.
+			if c, ok := model.ConsumableForKey(ev.Rune()); ok && !gameState.GameOver {
+				handler.UseConsumable(c)
 			}
 			return false
 		}
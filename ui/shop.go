@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ShopOffer is something the player can buy with gold between runs
+type ShopOffer struct {
+	Name        string
+	Description string
+	Price       int
+	SoldOut     bool // Already bought, or bought as often as possible
+}
+
+const (
+	shopText   = "THE LANISTA'S SHOP"
+	shopHelper = "Use UP/DOWN arrows to select, ENTER to buy, ESC to start the next run"
+)
+
+// ShowShop lets the player spend gold until they leave with escape
+func ShowShop(screen tcell.Screen, handler InputHandler) {
+	selected := 0
+	message := ""
+
+	draw := func(gold int, offers []ShopOffer) {
+		screen.Clear()
+		printText(screen, 2, 1, shopText, titleStyle)
+		printText(screen, 2, 3, fmt.Sprintf("💰 Gold: %d", gold), criticalStyle)
+
+		for i, offer := range offers {
+			style, prefix := infoStyle, prefixUnselected
+			if i == selected {
+				style, prefix = selectedStyle, prefixSelected
+			}
+			price := fmt.Sprintf("%d gold", offer.Price)
+			if offer.SoldOut {
+				price = "owned"
+			}
+			printText(screen, 2, 5+i, fmt.Sprintf("%s%s - %s [%s]", prefix, offer.Name, offer.Description, price), style)
+		}
+
+		printText(screen, 2, 6+len(offers), message, enemyStyle)
+		printText(screen, 2, 8+len(offers), shopHelper, infoStyle)
+		screen.Show()
+	}
+
+	for {
+		gold, offers := handler.ShopOffers()
+		if len(offers) == 0 {
+			return
+		}
+		draw(gold, offers)
+
+		switch ev := screen.PollEvent().(type) {
+		case *tcell.EventKey:
+			message = ""
+			switch ev.Key() {
+			case tcell.KeyUp:
+				selected = (selected - 1 + len(offers)) % len(offers)
+			case tcell.KeyDown:
+				selected = (selected + 1) % len(offers)
+			case tcell.KeyEnter:
+				if err := handler.Buy(selected); err != nil {
+					message = err.Error()
+				}
+			case tcell.KeyEscape:
+				return
+			}
+		case *tcell.EventResize:
+			screen.Sync()
+		}
+	}
+}
@@ -7,8 +7,99 @@ import (
 	"github.com/mattn/go-runewidth"
 )
 
-// ShowStartScreen displays the welcome screen and gets the player's name
-func ShowStartScreen(screen tcell.Screen) string {
+// StartChoice is what the player picked on the start screen
+type StartChoice struct {
+	Continue   bool   // Resume the saved run instead of starting a new one
+	PlayerName string // Name for a new run
+	Class      string // Hero class for a new run
+	Patron     string // Patron god for a new run
+	Tactical   bool   // Pick the hero's action every turn instead of auto-battling
+}
+
+// MenuOption is a single entry of a start screen menu
+type MenuOption struct {
+	Name        string
+	Description string
+}
+
+// ShowStartScreen displays the welcome screen and gets the player's name, class and patron,
+// offering to continue the saved run first when there is one
+func ShowStartScreen(screen tcell.Screen, hasSave bool, classes, patrons []MenuOption) StartChoice {
+	if hasSave {
+		options := []MenuOption{{Name: "Continue"}, {Name: "New Run"}}
+		if showMenu(screen, "A saved run awaits you:", options) == 0 {
+			return StartChoice{Continue: true}
+		}
+	}
+
+	choice := StartChoice{PlayerName: readPlayerName(screen)}
+	if len(classes) > 0 {
+		// Escape picks the first class
+		choice.Class = classes[max(0, showMenu(screen, "Choose your fighting style, "+choice.PlayerName+":", classes))].Name
+	}
+	if len(patrons) > 0 {
+		// Escape picks the first patron
+		choice.Patron = patrons[max(0, showMenu(screen, "Which god do you fight for?", patrons))].Name
+	}
+
+	modes := []MenuOption{
+		{Name: "Auto-battle", Description: "Battles play out on their own"},
+		{Name: "Tactical", Description: "Pick Attack, Heavy Strike, Defend or Taunt every turn"},
+	}
+	choice.Tactical = showMenu(screen, "How do you want to fight?", modes) == 1
+	return choice
+}
+
+// showMenu lets the player pick one of the options and returns its index, or -1 on escape
+func showMenu(screen tcell.Screen, prompt string, options []MenuOption) int {
+	titleStyle := tcell.StyleDefault.Bold(true).Foreground(tcell.ColorYellow)
+	promptStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+
+	selected := 0
+
+	draw := func() {
+		screen.Clear()
+		printText(screen, 10, 5, "WELCOME TO ROGUELIKE GLADIATOR ARENA", titleStyle)
+		printText(screen, 10, 8, prompt, promptStyle)
+		for i, option := range options {
+			style, prefix := infoStyle, prefixUnselected
+			if i == selected {
+				style, prefix = selectedStyle, prefixSelected
+			}
+			text := prefix + option.Name
+			if option.Description != "" {
+				text += " - " + option.Description
+			}
+			printText(screen, 10, 10+i, text, style)
+		}
+		printText(screen, 10, 12+len(options), upgradeHelper, promptStyle)
+		screen.Show()
+	}
+
+	draw()
+	for {
+		switch ev := screen.PollEvent().(type) {
+		case *tcell.EventKey:
+			switch ev.Key() {
+			case tcell.KeyUp:
+				selected = (selected - 1 + len(options)) % len(options)
+			case tcell.KeyDown:
+				selected = (selected + 1) % len(options)
+			case tcell.KeyEnter:
+				return selected
+			case tcell.KeyEscape:
+				return -1
+			}
+			draw()
+		case *tcell.EventResize:
+			screen.Sync()
+			draw()
+		}
+	}
+}
+
+// readPlayerName prompts for the player's name
+func readPlayerName(screen tcell.Screen) string {
 	screen.Clear()
 
 	defaultStyle := tcell.StyleDefault